@@ -1,12 +1,15 @@
 package client
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -30,6 +33,16 @@ const (
 
 var (
 	defaultClient atomic.Value
+	// noStatusView holds the URLs of the servers known not to support the status view,
+	// it outlives the clients which are created again each time the config is saved.
+	noStatusView sync.Map
+)
+
+var (
+	// ErrNotModified is returned by the conditional requests if the content has not changed.
+	ErrNotModified = errors.New("not modified")
+	// ErrStatusViewUnsupported is returned by GetServiceStatus if the server does not support the status view.
+	ErrStatusViewUnsupported = errors.New("status view is not supported")
 )
 
 func init() {
@@ -68,6 +81,13 @@ type Client struct {
 	client   http.Client
 	url      string
 	userinfo *url.Userinfo
+
+	// etag and sum identify the config got last by GetConfigIfModified.
+	etag string
+	sum  [sha256.Size]byte
+	// dirty is set by the requests changing the config on the server.
+	dirty atomic.Bool
+	mu    sync.Mutex
 }
 
 func NewClient(url string, opts ...Option) *Client {
@@ -92,7 +112,21 @@ func NewClient(url string, opts ...Option) *Client {
 	}
 }
 
+// Dirty reports whether the config has been changed by the client since it was got last.
+func (c *Client) Dirty() bool {
+	return c.dirty.Load()
+}
+
 func (c *Client) do(req *http.Request) (io.ReadCloser, error) {
+	resp, err := c.send(req)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// send sends the request, the response is returned only if its status is OK.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
 	if req == nil {
 		return nil, nil
 	}
@@ -108,6 +142,15 @@ func (c *Client) do(req *http.Request) (io.ReadCloser, error) {
 		return nil, err
 	}
 
+	if req.Method != http.MethodGet {
+		c.dirty.Store(true)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
 
@@ -116,5 +159,5 @@ func (c *Client) do(req *http.Request) (io.ReadCloser, error) {
 		return nil, fmt.Errorf("%d %s", rsp.Code, rsp.Msg)
 	}
 
-	return resp.Body, nil
+	return resp, nil
 }
@@ -2,10 +2,14 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/go-gost/gostctl/api"
@@ -37,6 +41,169 @@ func (c *Client) GetConfig(ctx context.Context) (*api.Config, error) {
 	return cfg, nil
 }
 
+// GetConfigIfModified gets the config, and reports whether it has changed since the last call apart from the status of the services.
+// The change is detected by the ETag of the server, or the hash of the content if the server does not send one.
+// The config is nil if the server reports it is not modified.
+func (c *Client) GetConfigIfModified(ctx context.Context) (cfg *api.Config, modified bool, err error) {
+	if c.url == "" {
+		return &api.Config{}, true, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+uriConfig, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the config is changed by this client since the last call.
+	if c.dirty.Swap(false) {
+		c.etag = ""
+		c.sum = [sha256.Size]byte{}
+	}
+	if c.etag != "" {
+		req.Header.Set("If-None-Match", c.etag)
+	}
+
+	resp, err := c.send(req)
+	if errors.Is(err, ErrNotModified) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	cfg = &api.Config{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, false, err
+	}
+
+	etag := resp.Header.Get("ETag")
+	sum, err := configSum(b)
+	if err != nil {
+		return nil, false, err
+	}
+	modified = etag != "" || sum != c.sum
+	c.etag = etag
+	c.sum = sum
+
+	return cfg, modified, nil
+}
+
+// configSum returns the hash of the raw config without the status of the services, which changes all the time.
+// The fields are kept raw, only the services are split to strip their status.
+func configSum(b []byte) (sum [sha256.Size]byte, err error) {
+	var view map[string]json.RawMessage
+	if err := json.Unmarshal(b, &view); err != nil {
+		return sum, err
+	}
+	var services []map[string]json.RawMessage
+	if err := json.Unmarshal(view["services"], &services); err != nil && view["services"] != nil {
+		return sum, err
+	}
+
+	h := sha256.New()
+	write := func(m map[string]json.RawMessage) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			h.Write([]byte(k))
+			h.Write([]byte{0})
+			h.Write(m[k])
+			h.Write([]byte{0})
+		}
+	}
+
+	delete(view, "services")
+	write(view)
+	for _, svc := range services {
+		delete(svc, "status")
+		write(svc)
+	}
+
+	h.Sum(sum[:0])
+	return sum, nil
+}
+
+// GetServiceStatus gets the status of the services by name, without the rest of the config.
+// ErrStatusViewUnsupported is returned if the server sends the full config instead of the status view,
+// the status view is not requested again from the server then.
+func (c *Client) GetServiceStatus(ctx context.Context) (map[string]*api.ServiceStatus, error) {
+	if c.url == "" {
+		return nil, nil
+	}
+	if _, ok := noStatusView.Load(c.url); ok {
+		return nil, ErrStatusViewUnsupported
+	}
+
+	values := url.Values{}
+	values.Set("view", "status")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+uriConfig+"?"+values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	var view map[string]json.RawMessage
+	if err := json.NewDecoder(resp).Decode(&view); err != nil {
+		return nil, err
+	}
+	var services []map[string]json.RawMessage
+	if err := json.Unmarshal(view["services"], &services); err != nil && view["services"] != nil {
+		return nil, err
+	}
+
+	// the status view has the name and status of the services only.
+	supported := true
+	for k := range view {
+		if k != "services" {
+			supported = false
+		}
+	}
+	for _, svc := range services {
+		for k := range svc {
+			if k != "name" && k != "status" {
+				supported = false
+			}
+		}
+	}
+	if !supported {
+		noStatusView.Store(c.url, struct{}{})
+		return nil, ErrStatusViewUnsupported
+	}
+
+	status := make(map[string]*api.ServiceStatus, len(services))
+	for _, svc := range services {
+		var name string
+		var st *api.ServiceStatus
+		if err := json.Unmarshal(svc["name"], &name); err != nil {
+			return nil, err
+		}
+		if svc["status"] != nil {
+			if err := json.Unmarshal(svc["status"], &st); err != nil {
+				return nil, err
+			}
+		}
+		status[name] = st
+	}
+	return status, nil
+}
+
 func (c *Client) SaveConfig(ctx context.Context, filepath string) error {
 	if c.url == "" {
 		return nil
@@ -220,18 +220,6 @@ type RecorderObject struct {
 	Metadata map[string]any
 }
 
-func (p *RecorderObject) copy() *RecorderObject {
-	if p == nil {
-		return nil
-	}
-
-	cfg := &RecorderObject{}
-	*cfg = *p
-	cfg.Metadata = copyMetadata(p.Metadata)
-
-	return cfg
-}
-
 type LimiterConfig struct {
 	Name   string        `json:"name"`
 	Limits []string      `yaml:",omitempty" json:"limits,omitempty"`
@@ -258,28 +246,6 @@ type ListenerConfig struct {
 	Metadata   map[string]any    `yaml:",omitempty" json:"metadata,omitempty"`
 }
 
-func (p *ListenerConfig) copy() *ListenerConfig {
-	if p == nil {
-		return nil
-	}
-
-	cfg := &ListenerConfig{}
-	*cfg = *p
-
-	cfg.ChainGroup = p.ChainGroup.copy()
-	if p.Auth != nil {
-		cfg.Auth = &AuthConfig{}
-		*cfg.Auth = *p.Auth
-	}
-	if p.TLS != nil {
-		cfg.TLS = &TLSConfig{}
-		*cfg.TLS = *p.TLS
-	}
-	cfg.Metadata = copyMetadata(p.Metadata)
-
-	return cfg
-}
-
 type HandlerConfig struct {
 	Type       string            `json:"type"`
 	Retries    int               `yaml:",omitempty" json:"retries,omitempty"`
@@ -294,56 +260,12 @@ type HandlerConfig struct {
 	Metadata   map[string]any    `yaml:",omitempty" json:"metadata,omitempty"`
 }
 
-func (p *HandlerConfig) copy() *HandlerConfig {
-	if p == nil {
-		return nil
-	}
-
-	cfg := &HandlerConfig{}
-	*cfg = *p
-	cfg.ChainGroup = p.ChainGroup.copy()
-	if p.Auth != nil {
-		cfg.Auth = &AuthConfig{}
-		*cfg.Auth = *p.Auth
-	}
-	if p.TLS != nil {
-		cfg.TLS = &TLSConfig{}
-		*cfg.TLS = *p.TLS
-	}
-	cfg.Metadata = copyMetadata(p.Metadata)
-
-	return cfg
-}
-
 type ForwarderConfig struct {
 	Hop      string               `yaml:",omitempty" json:"hop,omitempty"`
 	Selector *SelectorConfig      `yaml:",omitempty" json:"selector,omitempty"`
 	Nodes    []*ForwardNodeConfig `json:"nodes"`
 }
 
-func (p *ForwarderConfig) copy() *ForwarderConfig {
-	if p == nil {
-		return nil
-	}
-
-	cfg := &ForwarderConfig{}
-	*cfg = *p
-
-	if p.Selector != nil {
-		cfg.Selector = &SelectorConfig{}
-		*cfg.Selector = *p.Selector
-	}
-
-	if p.Nodes != nil {
-		cfg.Nodes = nil
-		for _, node := range p.Nodes {
-			cfg.Nodes = append(cfg.Nodes, node.copy())
-		}
-	}
-
-	return cfg
-}
-
 type ForwardNodeConfig struct {
 	Name     string   `yaml:",omitempty" json:"name,omitempty"`
 	Addr     string   `yaml:",omitempty" json:"addr,omitempty"`
@@ -363,33 +285,6 @@ type ForwardNodeConfig struct {
 	TLS    *TLSNodeConfig    `yaml:",omitempty" json:"tls,omitempty"`
 }
 
-func (p *ForwardNodeConfig) copy() *ForwardNodeConfig {
-	if p == nil {
-		return nil
-	}
-
-	cfg := &ForwardNodeConfig{}
-	*cfg = *p
-
-	if p.Auth != nil {
-		cfg.Auth = &AuthConfig{}
-		*cfg.Auth = *p.Auth
-	}
-	if p.Filter != nil {
-		cfg.Filter = &NodeFilterConfig{}
-		*cfg.Filter = *p.Filter
-	}
-	if p.HTTP != nil {
-		cfg.HTTP = &HTTPNodeConfig{}
-		*cfg.HTTP = *p.HTTP
-	}
-	if p.TLS != nil {
-		cfg.TLS = &TLSNodeConfig{}
-		*cfg.TLS = *p.TLS
-	}
-	return cfg
-}
-
 type NodeFilterConfig struct {
 	Protocol string `yaml:",omitempty" json:"protocol,omitempty"`
 	Host     string `yaml:",omitempty" json:"host,omitempty"`
@@ -460,33 +355,6 @@ type ServiceConfig struct {
 	Status *ServiceStatus `yaml:",omitempty" json:"status,omitempty"`
 }
 
-func (p *ServiceConfig) Copy() *ServiceConfig {
-	if p == nil {
-		return nil
-	}
-	cfg := &ServiceConfig{}
-	*cfg = *p
-
-	if p.SockOpts != nil {
-		cfg.SockOpts = &SockOptsConfig{}
-		*cfg.SockOpts = *p.SockOpts
-	}
-
-	if p.Recorders != nil {
-		cfg.Recorders = nil
-		for _, recorder := range p.Recorders {
-			cfg.Recorders = append(cfg.Recorders, recorder.copy())
-		}
-	}
-
-	cfg.Handler = p.Handler.copy()
-	cfg.Listener = p.Listener.copy()
-	cfg.Forwarder = p.Forwarder.copy()
-	cfg.Metadata = copyMetadata(p.Metadata)
-
-	return cfg
-}
-
 type ServiceStatus struct {
 	CreateTime int64          `yaml:"createTime" json:"createTime"`
 	State      string         `yaml:"state" json:"state"`
@@ -522,17 +390,6 @@ type ChainGroupConfig struct {
 	Selector *SelectorConfig `yaml:",omitempty" json:"selector,omitempty"`
 }
 
-func (p *ChainGroupConfig) copy() *ChainGroupConfig {
-	if p == nil {
-		return nil
-	}
-
-	cfg := &ChainGroupConfig{}
-	*cfg = *p
-
-	return cfg
-}
-
 type HopConfig struct {
 	Name      string          `json:"name"`
 	Interface string          `yaml:",omitempty" json:"interface,omitempty"`
@@ -648,26 +505,3 @@ type MetricsConfig struct {
 	Auth   *AuthConfig `yaml:",omitempty" json:"auth,omitempty"`
 	Auther string      `yaml:",omitempty" json:"auther,omitempty"`
 }
-
-func copyStrings(ss []string) []string {
-	if ss == nil {
-		return nil
-	}
-	v := make([]string, len(ss))
-	copy(v, ss)
-
-	return v
-}
-
-func copyMetadata(md map[string]any) map[string]any {
-	if md == nil {
-		return nil
-	}
-
-	m := make(map[string]any)
-	for k, v := range md {
-		m[k] = v
-	}
-
-	return m
-}
@@ -0,0 +1,1363 @@
+package api
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Copy returns a deep copy of the config, sharing nothing with it.
+func (p *Config) Copy() *Config {
+	if p == nil {
+		return nil
+	}
+
+	return &Config{
+		Services:   copyList(p.Services),
+		Chains:     copyList(p.Chains),
+		Hops:       copyList(p.Hops),
+		Authers:    copyList(p.Authers),
+		Admissions: copyList(p.Admissions),
+		Bypasses:   copyList(p.Bypasses),
+		Resolvers:  copyList(p.Resolvers),
+		Hosts:      copyList(p.Hosts),
+		Ingresses:  copyList(p.Ingresses),
+		Routers:    copyList(p.Routers),
+		SDs:        copyList(p.SDs),
+		Recorders:  copyList(p.Recorders),
+		Limiters:   copyList(p.Limiters),
+		CLimiters:  copyList(p.CLimiters),
+		RLimiters:  copyList(p.RLimiters),
+		Observers:  copyList(p.Observers),
+		Loggers:    copyList(p.Loggers),
+		TLS:        p.TLS.Copy(),
+		Log:        p.Log.Copy(),
+		Profiling:  p.Profiling.Copy(),
+		API:        p.API.Copy(),
+		Metrics:    p.Metrics.Copy(),
+	}
+}
+
+// Equal reports whether the config is the same as other, field by field.
+// A nil slice or map is equal to an empty one.
+func (p *Config) Equal(other *Config) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return equalList(p.Services, other.Services) &&
+		equalList(p.Chains, other.Chains) &&
+		equalList(p.Hops, other.Hops) &&
+		equalList(p.Authers, other.Authers) &&
+		equalList(p.Admissions, other.Admissions) &&
+		equalList(p.Bypasses, other.Bypasses) &&
+		equalList(p.Resolvers, other.Resolvers) &&
+		equalList(p.Hosts, other.Hosts) &&
+		equalList(p.Ingresses, other.Ingresses) &&
+		equalList(p.Routers, other.Routers) &&
+		equalList(p.SDs, other.SDs) &&
+		equalList(p.Recorders, other.Recorders) &&
+		equalList(p.Limiters, other.Limiters) &&
+		equalList(p.CLimiters, other.CLimiters) &&
+		equalList(p.RLimiters, other.RLimiters) &&
+		equalList(p.Observers, other.Observers) &&
+		equalList(p.Loggers, other.Loggers) &&
+		p.TLS.Equal(other.TLS) &&
+		p.Log.Equal(other.Log) &&
+		p.Profiling.Equal(other.Profiling) &&
+		p.API.Equal(other.API) &&
+		p.Metrics.Equal(other.Metrics)
+}
+
+func (p *AutherConfig) Copy() *AutherConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Auths = copyList(p.Auths)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *AutherConfig) Equal(other *AutherConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		equalList(p.Auths, other.Auths) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *AuthConfig) Copy() *AuthConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *AuthConfig) Equal(other *AuthConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *SelectorConfig) Copy() *SelectorConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *SelectorConfig) Equal(other *SelectorConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *AdmissionConfig) Copy() *AdmissionConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Matchers = slices.Clone(p.Matchers)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *AdmissionConfig) Equal(other *AdmissionConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Reverse == other.Reverse &&
+		p.Whitelist == other.Whitelist &&
+		slices.Equal(p.Matchers, other.Matchers) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *BypassConfig) Copy() *BypassConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Matchers = slices.Clone(p.Matchers)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *BypassConfig) Equal(other *BypassConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Reverse == other.Reverse &&
+		p.Whitelist == other.Whitelist &&
+		slices.Equal(p.Matchers, other.Matchers) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *FileLoader) Copy() *FileLoader {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *FileLoader) Equal(other *FileLoader) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *RedisLoader) Copy() *RedisLoader {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *RedisLoader) Equal(other *RedisLoader) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *HTTPLoader) Copy() *HTTPLoader {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *HTTPLoader) Equal(other *HTTPLoader) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *NameserverConfig) Copy() *NameserverConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *NameserverConfig) Equal(other *NameserverConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *ResolverConfig) Copy() *ResolverConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Nameservers = copyList(p.Nameservers)
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *ResolverConfig) Equal(other *ResolverConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		equalList(p.Nameservers, other.Nameservers) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *HostMappingConfig) Copy() *HostMappingConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Aliases = slices.Clone(p.Aliases)
+
+	return &cfg
+}
+
+func (p *HostMappingConfig) Equal(other *HostMappingConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.IP == other.IP &&
+		p.Hostname == other.Hostname &&
+		slices.Equal(p.Aliases, other.Aliases)
+}
+
+func (p *HostsConfig) Copy() *HostsConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Mappings = copyList(p.Mappings)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *HostsConfig) Equal(other *HostsConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		equalList(p.Mappings, other.Mappings) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *IngressRuleConfig) Copy() *IngressRuleConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *IngressRuleConfig) Equal(other *IngressRuleConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *IngressConfig) Copy() *IngressConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Rules = copyList(p.Rules)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *IngressConfig) Equal(other *IngressConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		equalList(p.Rules, other.Rules) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *SDConfig) Copy() *SDConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *SDConfig) Equal(other *SDConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *RouterRouteConfig) Copy() *RouterRouteConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *RouterRouteConfig) Equal(other *RouterRouteConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *RouterConfig) Copy() *RouterConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Routes = copyList(p.Routes)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *RouterConfig) Equal(other *RouterConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		equalList(p.Routes, other.Routes) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *RecorderConfig) Copy() *RecorderConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.File = p.File.Copy()
+	cfg.TCP = p.TCP.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *RecorderConfig) Equal(other *RecorderConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.File.Equal(other.File) &&
+		p.TCP.Equal(other.TCP) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Redis.Equal(other.Redis) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *FileRecorder) Copy() *FileRecorder {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *FileRecorder) Equal(other *FileRecorder) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *TCPRecorder) Copy() *TCPRecorder {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *TCPRecorder) Equal(other *TCPRecorder) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *HTTPRecorder) Copy() *HTTPRecorder {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *HTTPRecorder) Equal(other *HTTPRecorder) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *RedisRecorder) Copy() *RedisRecorder {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *RedisRecorder) Equal(other *RedisRecorder) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *RecorderObject) Copy() *RecorderObject {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *RecorderObject) Equal(other *RecorderObject) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Record == other.Record &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *LimiterConfig) Copy() *LimiterConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Limits = slices.Clone(p.Limits)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *LimiterConfig) Equal(other *LimiterConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		slices.Equal(p.Limits, other.Limits) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *ObserverConfig) Copy() *ObserverConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *ObserverConfig) Equal(other *ObserverConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *ListenerConfig) Copy() *ListenerConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.ChainGroup = p.ChainGroup.Copy()
+	cfg.Authers = slices.Clone(p.Authers)
+	cfg.Auth = p.Auth.Copy()
+	cfg.TLS = p.TLS.Copy()
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *ListenerConfig) Equal(other *ListenerConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Type == other.Type &&
+		p.Chain == other.Chain &&
+		p.ChainGroup.Equal(other.ChainGroup) &&
+		p.Auther == other.Auther &&
+		slices.Equal(p.Authers, other.Authers) &&
+		p.Auth.Equal(other.Auth) &&
+		p.TLS.Equal(other.TLS) &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *HandlerConfig) Copy() *HandlerConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.ChainGroup = p.ChainGroup.Copy()
+	cfg.Authers = slices.Clone(p.Authers)
+	cfg.Auth = p.Auth.Copy()
+	cfg.TLS = p.TLS.Copy()
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *HandlerConfig) Equal(other *HandlerConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Type == other.Type &&
+		p.Retries == other.Retries &&
+		p.Chain == other.Chain &&
+		p.ChainGroup.Equal(other.ChainGroup) &&
+		p.Auther == other.Auther &&
+		slices.Equal(p.Authers, other.Authers) &&
+		p.Auth.Equal(other.Auth) &&
+		p.TLS.Equal(other.TLS) &&
+		p.Limiter == other.Limiter &&
+		p.Observer == other.Observer &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *ForwarderConfig) Copy() *ForwarderConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Selector = p.Selector.Copy()
+	cfg.Nodes = copyList(p.Nodes)
+
+	return &cfg
+}
+
+func (p *ForwarderConfig) Equal(other *ForwarderConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Hop == other.Hop &&
+		p.Selector.Equal(other.Selector) &&
+		equalList(p.Nodes, other.Nodes)
+}
+
+func (p *ForwardNodeConfig) Copy() *ForwardNodeConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Bypasses = slices.Clone(p.Bypasses)
+	cfg.Auth = p.Auth.Copy()
+	cfg.Filter = p.Filter.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.TLS = p.TLS.Copy()
+
+	return &cfg
+}
+
+func (p *ForwardNodeConfig) Equal(other *ForwardNodeConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Addr == other.Addr &&
+		p.Network == other.Network &&
+		p.Bypass == other.Bypass &&
+		slices.Equal(p.Bypasses, other.Bypasses) &&
+		p.Protocol == other.Protocol &&
+		p.Host == other.Host &&
+		p.Path == other.Path &&
+		p.Auth.Equal(other.Auth) &&
+		p.Filter.Equal(other.Filter) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.TLS.Equal(other.TLS)
+}
+
+func (p *NodeFilterConfig) Copy() *NodeFilterConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *NodeFilterConfig) Equal(other *NodeFilterConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *HTTPURLRewriteConfig) Copy() *HTTPURLRewriteConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *HTTPURLRewriteConfig) Equal(other *HTTPURLRewriteConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *HTTPNodeConfig) Copy() *HTTPNodeConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Header = maps.Clone(p.Header)
+	cfg.Auth = p.Auth.Copy()
+	cfg.Rewrite = slices.Clone(p.Rewrite)
+
+	return &cfg
+}
+
+func (p *HTTPNodeConfig) Equal(other *HTTPNodeConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Host == other.Host &&
+		maps.Equal(p.Header, other.Header) &&
+		p.Auth.Equal(other.Auth) &&
+		slices.Equal(p.Rewrite, other.Rewrite)
+}
+
+func (p *TLSNodeConfig) Copy() *TLSNodeConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Options = p.Options.Copy()
+
+	return &cfg
+}
+
+func (p *TLSNodeConfig) Equal(other *TLSNodeConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.ServerName == other.ServerName &&
+		p.Secure == other.Secure &&
+		p.Options.Equal(other.Options)
+}
+
+func (p *DialerConfig) Copy() *DialerConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Auth = p.Auth.Copy()
+	cfg.TLS = p.TLS.Copy()
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *DialerConfig) Equal(other *DialerConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Type == other.Type &&
+		p.Auth.Equal(other.Auth) &&
+		p.TLS.Equal(other.TLS) &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *ConnectorConfig) Copy() *ConnectorConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Auth = p.Auth.Copy()
+	cfg.TLS = p.TLS.Copy()
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *ConnectorConfig) Equal(other *ConnectorConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Type == other.Type &&
+		p.Auth.Equal(other.Auth) &&
+		p.TLS.Equal(other.TLS) &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *SockOptsConfig) Copy() *SockOptsConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *SockOptsConfig) Equal(other *SockOptsConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *ServiceConfig) Copy() *ServiceConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.SockOpts = p.SockOpts.Copy()
+	cfg.Admissions = slices.Clone(p.Admissions)
+	cfg.Bypasses = slices.Clone(p.Bypasses)
+	cfg.Loggers = slices.Clone(p.Loggers)
+	cfg.Recorders = copyList(p.Recorders)
+	cfg.Handler = p.Handler.Copy()
+	cfg.Listener = p.Listener.Copy()
+	cfg.Forwarder = p.Forwarder.Copy()
+	cfg.Metadata = copyMetadata(p.Metadata)
+	cfg.Status = p.Status.Copy()
+
+	return &cfg
+}
+
+func (p *ServiceConfig) Equal(other *ServiceConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Addr == other.Addr &&
+		p.Interface == other.Interface &&
+		p.SockOpts.Equal(other.SockOpts) &&
+		p.Admission == other.Admission &&
+		slices.Equal(p.Admissions, other.Admissions) &&
+		p.Bypass == other.Bypass &&
+		slices.Equal(p.Bypasses, other.Bypasses) &&
+		p.Resolver == other.Resolver &&
+		p.Hosts == other.Hosts &&
+		p.Limiter == other.Limiter &&
+		p.CLimiter == other.CLimiter &&
+		p.RLimiter == other.RLimiter &&
+		p.Logger == other.Logger &&
+		slices.Equal(p.Loggers, other.Loggers) &&
+		p.Observer == other.Observer &&
+		equalList(p.Recorders, other.Recorders) &&
+		p.Handler.Equal(other.Handler) &&
+		p.Listener.Equal(other.Listener) &&
+		p.Forwarder.Equal(other.Forwarder) &&
+		equalMetadata(p.Metadata, other.Metadata) &&
+		p.Status.Equal(other.Status)
+}
+
+func (p *ServiceStatus) Copy() *ServiceStatus {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Events = slices.Clone(p.Events)
+	cfg.Stats = p.Stats.Copy()
+
+	return &cfg
+}
+
+func (p *ServiceStatus) Equal(other *ServiceStatus) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.CreateTime == other.CreateTime &&
+		p.State == other.State &&
+		slices.Equal(p.Events, other.Events) &&
+		p.Stats.Equal(other.Stats)
+}
+
+func (p *ServiceEvent) Copy() *ServiceEvent {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *ServiceEvent) Equal(other *ServiceEvent) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *ServiceStats) Copy() *ServiceStats {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *ServiceStats) Equal(other *ServiceStats) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	// the same instant in different locations is equal.
+	a, b := *p, *other
+	a.Time, b.Time = time.Time{}, time.Time{}
+	return p.Time.Equal(other.Time) && a == b
+}
+
+func (p *ChainConfig) Copy() *ChainConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Hops = copyList(p.Hops)
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *ChainConfig) Equal(other *ChainConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		equalList(p.Hops, other.Hops) &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *ChainGroupConfig) Copy() *ChainGroupConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Chains = slices.Clone(p.Chains)
+	cfg.Selector = p.Selector.Copy()
+
+	return &cfg
+}
+
+func (p *ChainGroupConfig) Equal(other *ChainGroupConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return slices.Equal(p.Chains, other.Chains) &&
+		p.Selector.Equal(other.Selector)
+}
+
+func (p *HopConfig) Copy() *HopConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.SockOpts = p.SockOpts.Copy()
+	cfg.Selector = p.Selector.Copy()
+	cfg.Bypasses = slices.Clone(p.Bypasses)
+	cfg.Nodes = copyList(p.Nodes)
+	cfg.File = p.File.Copy()
+	cfg.Redis = p.Redis.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.Plugin = p.Plugin.Copy()
+
+	return &cfg
+}
+
+func (p *HopConfig) Equal(other *HopConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Interface == other.Interface &&
+		p.SockOpts.Equal(other.SockOpts) &&
+		p.Selector.Equal(other.Selector) &&
+		p.Bypass == other.Bypass &&
+		slices.Equal(p.Bypasses, other.Bypasses) &&
+		p.Resolver == other.Resolver &&
+		p.Hosts == other.Hosts &&
+		equalList(p.Nodes, other.Nodes) &&
+		p.Reload == other.Reload &&
+		p.File.Equal(other.File) &&
+		p.Redis.Equal(other.Redis) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.Plugin.Equal(other.Plugin)
+}
+
+func (p *NodeConfig) Copy() *NodeConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Bypasses = slices.Clone(p.Bypasses)
+	cfg.Connector = p.Connector.Copy()
+	cfg.Dialer = p.Dialer.Copy()
+	cfg.SockOpts = p.SockOpts.Copy()
+	cfg.Filter = p.Filter.Copy()
+	cfg.HTTP = p.HTTP.Copy()
+	cfg.TLS = p.TLS.Copy()
+	cfg.Metadata = copyMetadata(p.Metadata)
+
+	return &cfg
+}
+
+func (p *NodeConfig) Equal(other *NodeConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Addr == other.Addr &&
+		p.Network == other.Network &&
+		p.Bypass == other.Bypass &&
+		slices.Equal(p.Bypasses, other.Bypasses) &&
+		p.Resolver == other.Resolver &&
+		p.Hosts == other.Hosts &&
+		p.Connector.Equal(other.Connector) &&
+		p.Dialer.Equal(other.Dialer) &&
+		p.Interface == other.Interface &&
+		p.SockOpts.Equal(other.SockOpts) &&
+		p.Filter.Equal(other.Filter) &&
+		p.HTTP.Equal(other.HTTP) &&
+		p.TLS.Equal(other.TLS) &&
+		equalMetadata(p.Metadata, other.Metadata)
+}
+
+func (p *TLSConfig) Copy() *TLSConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Options = p.Options.Copy()
+
+	return &cfg
+}
+
+func (p *TLSConfig) Equal(other *TLSConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.CertFile == other.CertFile &&
+		p.KeyFile == other.KeyFile &&
+		p.CAFile == other.CAFile &&
+		p.Secure == other.Secure &&
+		p.ServerName == other.ServerName &&
+		p.Options.Equal(other.Options) &&
+		p.Validity == other.Validity &&
+		p.CommonName == other.CommonName &&
+		p.Organization == other.Organization
+}
+
+func (p *TLSOptions) Copy() *TLSOptions {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.CipherSuites = slices.Clone(p.CipherSuites)
+
+	return &cfg
+}
+
+func (p *TLSOptions) Equal(other *TLSOptions) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.MinVersion == other.MinVersion &&
+		p.MaxVersion == other.MaxVersion &&
+		slices.Equal(p.CipherSuites, other.CipherSuites)
+}
+
+func (p *PluginConfig) Copy() *PluginConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.TLS = p.TLS.Copy()
+
+	return &cfg
+}
+
+func (p *PluginConfig) Equal(other *PluginConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Type == other.Type &&
+		p.Addr == other.Addr &&
+		p.TLS.Equal(other.TLS) &&
+		p.Timeout == other.Timeout &&
+		p.Token == other.Token
+}
+
+func (p *LogConfig) Copy() *LogConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Rotation = p.Rotation.Copy()
+
+	return &cfg
+}
+
+func (p *LogConfig) Equal(other *LogConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Output == other.Output &&
+		p.Level == other.Level &&
+		p.Format == other.Format &&
+		p.Rotation.Equal(other.Rotation)
+}
+
+func (p *LogRotationConfig) Copy() *LogRotationConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *LogRotationConfig) Equal(other *LogRotationConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *LoggerConfig) Copy() *LoggerConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Log = p.Log.Copy()
+
+	return &cfg
+}
+
+func (p *LoggerConfig) Equal(other *LoggerConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Name == other.Name &&
+		p.Log.Equal(other.Log)
+}
+
+func (p *ProfilingConfig) Copy() *ProfilingConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	return &cfg
+}
+
+func (p *ProfilingConfig) Equal(other *ProfilingConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+func (p *APIConfig) Copy() *APIConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Auth = p.Auth.Copy()
+
+	return &cfg
+}
+
+func (p *APIConfig) Equal(other *APIConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Addr == other.Addr &&
+		p.PathPrefix == other.PathPrefix &&
+		p.AccessLog == other.AccessLog &&
+		p.Auth.Equal(other.Auth) &&
+		p.Auther == other.Auther
+}
+
+func (p *MetricsConfig) Copy() *MetricsConfig {
+	if p == nil {
+		return nil
+	}
+
+	cfg := *p
+	cfg.Auth = p.Auth.Copy()
+
+	return &cfg
+}
+
+func (p *MetricsConfig) Equal(other *MetricsConfig) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.Addr == other.Addr &&
+		p.Path == other.Path &&
+		p.Auth.Equal(other.Auth) &&
+		p.Auther == other.Auther
+}
+
+type copier[T any] interface {
+	Copy() T
+}
+
+// copyList returns a deep copy of the list, a nil list is kept nil.
+func copyList[T copier[T]](list []T) []T {
+	if list == nil {
+		return nil
+	}
+
+	v := make([]T, len(list))
+	for i := range list {
+		v[i] = list[i].Copy()
+	}
+	return v
+}
+
+func equalList[T interface{ Equal(T) bool }](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyMetadata returns a deep copy of the metadata, including the nested maps and lists.
+func copyMetadata(md map[string]any) map[string]any {
+	if md == nil {
+		return nil
+	}
+
+	m := make(map[string]any, len(md))
+	for k, v := range md {
+		m[k] = copyValue(v)
+	}
+
+	return m
+}
+
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return copyMetadata(v)
+	case map[any]any:
+		if v == nil {
+			return v
+		}
+		m := make(map[any]any, len(v))
+		for k, vv := range v {
+			m[k] = copyValue(vv)
+		}
+		return m
+	case []any:
+		if v == nil {
+			return v
+		}
+		list := make([]any, len(v))
+		for i := range v {
+			list[i] = copyValue(v[i])
+		}
+		return list
+	case map[string]string:
+		return maps.Clone(v)
+	case []string:
+		return slices.Clone(v)
+	default:
+		return v
+	}
+}
+
+func equalMetadata(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// configs are the config types with the Copy and Equal methods.
+var configs = []any{
+	&Config{},
+	&AutherConfig{},
+	&AuthConfig{},
+	&SelectorConfig{},
+	&AdmissionConfig{},
+	&BypassConfig{},
+	&FileLoader{},
+	&RedisLoader{},
+	&HTTPLoader{},
+	&NameserverConfig{},
+	&ResolverConfig{},
+	&HostMappingConfig{},
+	&HostsConfig{},
+	&IngressRuleConfig{},
+	&IngressConfig{},
+	&SDConfig{},
+	&RouterRouteConfig{},
+	&RouterConfig{},
+	&RecorderConfig{},
+	&FileRecorder{},
+	&TCPRecorder{},
+	&HTTPRecorder{},
+	&RedisRecorder{},
+	&RecorderObject{},
+	&LimiterConfig{},
+	&ObserverConfig{},
+	&ListenerConfig{},
+	&HandlerConfig{},
+	&ForwarderConfig{},
+	&ForwardNodeConfig{},
+	&NodeFilterConfig{},
+	&HTTPURLRewriteConfig{},
+	&HTTPNodeConfig{},
+	&TLSNodeConfig{},
+	&DialerConfig{},
+	&ConnectorConfig{},
+	&SockOptsConfig{},
+	&ServiceConfig{},
+	&ServiceStatus{},
+	&ServiceEvent{},
+	&ServiceStats{},
+	&ChainConfig{},
+	&ChainGroupConfig{},
+	&HopConfig{},
+	&NodeConfig{},
+	&TLSConfig{},
+	&TLSOptions{},
+	&PluginConfig{},
+	&LogConfig{},
+	&LogRotationConfig{},
+	&LoggerConfig{},
+	&ProfilingConfig{},
+	&APIConfig{},
+	&MetricsConfig{},
+}
+
+// filler sets every field to a distinct non-zero value.
+type filler struct {
+	n int
+}
+
+func (f *filler) fill(v reflect.Value) {
+	f.n++
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		f.fill(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(time.Unix(int64(f.n), 0)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f.fill(v.Field(i))
+		}
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), 2, 2)
+		for i := 0; i < s.Len(); i++ {
+			f.fill(s.Index(i))
+		}
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		if v.Type().Elem().Kind() == reflect.Interface {
+			// nested metadata.
+			m.SetMapIndex(reflect.ValueOf("nested"), reflect.ValueOf(map[string]any{
+				"list": []any{f.n, map[string]any{"key": "value"}},
+				"map":  map[any]any{"key": []string{"value"}},
+			}))
+			m.SetMapIndex(reflect.ValueOf("value"), reflect.ValueOf(f.n))
+		} else {
+			k, e := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+			f.fill(k)
+			f.fill(e)
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	case reflect.String:
+		v.SetString(fmt.Sprintf("s%d", f.n))
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(int64(f.n))
+	case reflect.Uint64:
+		v.SetUint(uint64(f.n))
+	case reflect.Float64:
+		v.SetFloat(float64(f.n) + 0.5)
+	default:
+		panic(fmt.Sprintf("unsupported kind %s of %s", v.Kind(), v.Type()))
+	}
+}
+
+func call(v reflect.Value, method string, args ...reflect.Value) reflect.Value {
+	return v.MethodByName(method).Call(args)[0]
+}
+
+// checkShared reports the pointers, slices and maps shared by a and b.
+func checkShared(t *testing.T, path string, a, b reflect.Value) {
+	t.Helper()
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if !a.IsNil() && a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared", path)
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !a.IsNil() {
+			checkShared(t, path, a.Elem(), b.Elem())
+		}
+	case reflect.Struct:
+		// the location of time.Time is immutable.
+		if a.Type() == timeType {
+			return
+		}
+		for i := 0; i < a.NumField(); i++ {
+			checkShared(t, path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i))
+		}
+	case reflect.Slice:
+		for i := 0; i < a.Len(); i++ {
+			checkShared(t, fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+	case reflect.Map:
+		for _, k := range a.MapKeys() {
+			checkShared(t, fmt.Sprintf("%s[%v]", path, k), a.MapIndex(k), b.MapIndex(k))
+		}
+	}
+}
+
+// mutate changes each field of v in turn, calling check with the path of the field changed, and restores it.
+func mutate(v reflect.Value, path string, check func(path string)) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			mutate(v.Elem(), path, check)
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			old := v.Interface()
+			v.Set(reflect.ValueOf(time.Unix(1<<30, 0)))
+			check(path)
+			v.Set(reflect.ValueOf(old))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			mutate(v.Field(i), path+"."+v.Type().Field(i).Name, check)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			mutate(v.Index(i), fmt.Sprintf("%s[%d]", path, i), check)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			old := v.MapIndex(k)
+			v.SetMapIndex(k, reflect.ValueOf("changed").Convert(v.Type().Elem()))
+			check(fmt.Sprintf("%s[%v]", path, k))
+			v.SetMapIndex(k, old)
+		}
+	case reflect.String:
+		old := v.String()
+		v.SetString(old + "-changed")
+		check(path)
+		v.SetString(old)
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+		check(path)
+		v.SetBool(!v.Bool())
+	case reflect.Int, reflect.Int64:
+		v.SetInt(v.Int() + 1)
+		check(path)
+		v.SetInt(v.Int() - 1)
+	case reflect.Uint64:
+		v.SetUint(v.Uint() + 1)
+		check(path)
+		v.SetUint(v.Uint() - 1)
+	case reflect.Float64:
+		v.SetFloat(v.Float() + 1)
+		check(path)
+		v.SetFloat(v.Float() - 1)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	for _, cfg := range configs {
+		v := reflect.ValueOf(cfg)
+		name := v.Elem().Type().Name()
+
+		t.Run(name, func(t *testing.T) {
+			(&filler{}).fill(v.Elem())
+
+			c := call(v, "Copy")
+			if !reflect.DeepEqual(v.Interface(), c.Interface()) {
+				t.Fatalf("copy differs from the original")
+			}
+			checkShared(t, name, v, c)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for _, cfg := range configs {
+		v := reflect.ValueOf(cfg)
+		name := v.Elem().Type().Name()
+
+		t.Run(name, func(t *testing.T) {
+			(&filler{}).fill(v.Elem())
+
+			c := call(v, "Copy")
+			if !call(v, "Equal", c).Bool() {
+				t.Fatalf("copy is not equal to the original")
+			}
+
+			mutate(v, name, func(path string) {
+				if call(v, "Equal", c).Bool() || call(c, "Equal", v).Bool() {
+					t.Errorf("change of %s is not detected", path)
+				}
+			})
+			if !call(v, "Equal", c).Bool() {
+				t.Fatalf("original is not restored")
+			}
+
+			nilValue := reflect.Zero(v.Type())
+			if call(v, "Equal", nilValue).Bool() || call(nilValue, "Equal", v).Bool() {
+				t.Errorf("equal to nil")
+			}
+			if !call(nilValue, "Equal", nilValue).Bool() {
+				t.Errorf("nil is not equal to nil")
+			}
+			if !call(nilValue, "Copy").IsNil() {
+				t.Errorf("copy of nil is not nil")
+			}
+		})
+	}
+}
+
+func TestEqualEmpty(t *testing.T) {
+	a := &ServiceConfig{Metadata: map[string]any{}, Admissions: []string{}}
+	b := &ServiceConfig{}
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Errorf("empty and nil fields are not equal")
+	}
+}
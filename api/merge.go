@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Merge merges the changes made on local and remote since base into out, field by field.
+// The nested objects are merged recursively, a field changed on one side only takes the changed value,
+// a field changed on both sides takes the local value. The lists are not merged, they are replaced as a whole.
+// Any of base, local and remote can be nil.
+func Merge(base, local, remote any, out any) error {
+	baseFields, err := fields(base)
+	if err != nil {
+		return err
+	}
+	localFields, err := fields(local)
+	if err != nil {
+		return err
+	}
+	remoteFields, err := fields(remote)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(mergeFields(baseFields, localFields, remoteFields))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func mergeFields(base, local, remote map[string]any) map[string]any {
+	merged := make(map[string]any)
+
+	keys := make(map[string]bool)
+	for _, m := range []map[string]any{base, local, remote} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+
+	for k := range keys {
+		b, inBase := base[k]
+		l, inLocal := local[k]
+		r, inRemote := remote[k]
+
+		v, ok := r, inRemote
+		switch {
+		// not changed locally.
+		case inLocal == inBase && reflect.DeepEqual(l, b):
+		// changed locally only.
+		case inRemote == inBase && reflect.DeepEqual(r, b):
+			v, ok = l, inLocal
+		default:
+			lm, lok := l.(map[string]any)
+			rm, rok := r.(map[string]any)
+			if lok && rok {
+				bm, _ := b.(map[string]any)
+				v = mergeFields(bm, lm, rm)
+			} else {
+				v, ok = l, inLocal
+			}
+		}
+		if ok {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+func fields(v any) (map[string]any, error) {
+	m := map[string]any{}
+	if v == nil {
+		return m, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return m, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
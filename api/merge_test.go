@@ -0,0 +1,60 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := &ServiceConfig{
+		Name: "svc",
+		Addr: ":8080",
+		Handler: &HandlerConfig{
+			Type:  "http",
+			Chain: "chain-0",
+		},
+		Listener: &ListenerConfig{Type: "tcp"},
+		Limiter:  "limiter-0",
+	}
+
+	local := base.Copy()
+	local.Addr = ":8081"
+	local.Handler.Chain = "chain-1"
+	local.Limiter = ""
+
+	remote := base.Copy()
+	remote.Handler.Type = "socks5"
+	remote.Listener = nil
+	remote.Observer = "observer-0"
+
+	merged := &ServiceConfig{}
+	if err := Merge(base, local, remote, merged); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ServiceConfig{
+		Name: "svc",
+		Addr: ":8081",
+		Handler: &HandlerConfig{
+			Type:  "socks5",
+			Chain: "chain-1",
+		},
+		Observer: "observer-0",
+	}
+	if !merged.Equal(want) {
+		t.Errorf("merged %+v, want %+v", merged, want)
+	}
+}
+
+func TestMergeBothChanged(t *testing.T) {
+	base := &ServiceConfig{Name: "svc", Addr: ":8080"}
+	local := &ServiceConfig{Name: "svc", Addr: ":8081"}
+	remote := &ServiceConfig{Name: "svc", Addr: ":8082"}
+
+	merged := &ServiceConfig{}
+	if err := Merge(base, local, remote, merged); err != nil {
+		t.Fatal(err)
+	}
+	if merged.Addr != ":8081" {
+		t.Errorf("addr %s, want the local change", merged.Addr)
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+)
+
+// Migration is a config object that still uses deprecated fields,
+// together with its modernized replacement.
+type Migration struct {
+	// Kind is the resource type of the object: service, admission or bypass.
+	Kind string
+	Name string
+	// Changes describes each rewritten field.
+	Changes []string
+	// Value is the modernized object, one of *ServiceConfig, *AdmissionConfig or *BypassConfig.
+	Value any
+}
+
+// Migrate detects deprecated fields in cfg and returns the objects that need to be rewritten.
+// cfg itself is not modified.
+func Migrate(cfg *Config) (migrations []Migration) {
+	if cfg == nil {
+		return
+	}
+
+	for _, v := range cfg.Services {
+		if m := migrateService(v); m != nil {
+			migrations = append(migrations, *m)
+		}
+	}
+	for _, v := range cfg.Admissions {
+		if m := migrateAdmission(v); m != nil {
+			migrations = append(migrations, *m)
+		}
+	}
+	for _, v := range cfg.Bypasses {
+		if m := migrateBypass(v); m != nil {
+			migrations = append(migrations, *m)
+		}
+	}
+
+	return
+}
+
+func migrateService(svc *ServiceConfig) *Migration {
+	if svc == nil {
+		return nil
+	}
+
+	cfg := svc.Copy()
+	// status is read-only and must not be sent back.
+	cfg.Status = nil
+
+	var changes []string
+
+	if cfg.Interface != "" {
+		if cfg.Metadata == nil {
+			cfg.Metadata = make(map[string]any)
+		}
+		if _, ok := cfg.Metadata["interface"]; !ok {
+			cfg.Metadata["interface"] = cfg.Interface
+		}
+		changes = append(changes, fmt.Sprintf("interface: %s -> metadata.interface", cfg.Interface))
+		cfg.Interface = ""
+	}
+
+	if cfg.SockOpts != nil {
+		if cfg.SockOpts.Mark != 0 {
+			if cfg.Metadata == nil {
+				cfg.Metadata = make(map[string]any)
+			}
+			if _, ok := cfg.Metadata["so_mark"]; !ok {
+				cfg.Metadata["so_mark"] = cfg.SockOpts.Mark
+			}
+			changes = append(changes, fmt.Sprintf("sockopts.mark: %d -> metadata.so_mark", cfg.SockOpts.Mark))
+		}
+		// the empty sockopts is dropped along with the other changes, it is not a change by itself.
+		cfg.SockOpts = nil
+	}
+
+	if cfg.Forwarder != nil {
+		for i, node := range cfg.Forwarder.Nodes {
+			if node == nil {
+				continue
+			}
+			for _, change := range migrateForwardNode(node) {
+				changes = append(changes, fmt.Sprintf("forwarder.nodes[%d].%s", i, change))
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return &Migration{
+		Kind:    "service",
+		Name:    cfg.Name,
+		Changes: changes,
+		Value:   cfg,
+	}
+}
+
+// migrateForwardNode rewrites the deprecated fields of node in place.
+func migrateForwardNode(node *ForwardNodeConfig) (changes []string) {
+	if node.Protocol != "" || node.Host != "" || node.Path != "" {
+		// the fields set in the filter take precedence over the deprecated ones.
+		if node.Filter == nil {
+			node.Filter = &NodeFilterConfig{}
+		}
+		if node.Filter.Protocol == "" {
+			node.Filter.Protocol = node.Protocol
+		}
+		if node.Filter.Host == "" {
+			node.Filter.Host = node.Host
+		}
+		if node.Filter.Path == "" {
+			node.Filter.Path = node.Path
+		}
+		changes = append(changes, "protocol/host/path -> filter")
+		node.Protocol = ""
+		node.Host = ""
+		node.Path = ""
+	}
+
+	if node.Auth != nil {
+		if node.HTTP == nil {
+			node.HTTP = &HTTPNodeConfig{}
+		}
+		if node.HTTP.Auth == nil {
+			node.HTTP.Auth = node.Auth
+		}
+		changes = append(changes, "auth -> http.auth")
+		node.Auth = nil
+	}
+
+	return
+}
+
+func migrateAdmission(admission *AdmissionConfig) *Migration {
+	if admission == nil || !admission.Reverse {
+		return nil
+	}
+
+	cfg := &AdmissionConfig{}
+	*cfg = *admission
+	cfg.Whitelist = true
+	cfg.Reverse = false
+
+	return &Migration{
+		Kind:    "admission",
+		Name:    cfg.Name,
+		Changes: []string{"reverse -> whitelist"},
+		Value:   cfg,
+	}
+}
+
+func migrateBypass(bypass *BypassConfig) *Migration {
+	if bypass == nil || !bypass.Reverse {
+		return nil
+	}
+
+	cfg := &BypassConfig{}
+	*cfg = *bypass
+	cfg.Whitelist = true
+	cfg.Reverse = false
+
+	return &Migration{
+		Kind:    "bypass",
+		Name:    cfg.Name,
+		Changes: []string{"reverse -> whitelist"},
+		Value:   cfg,
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestMigrateServiceEmptySockOpts(t *testing.T) {
+	m := migrateService(&ServiceConfig{
+		Name:     "svc",
+		SockOpts: &SockOptsConfig{},
+	})
+	if m != nil {
+		t.Errorf("migration %v of empty sockopts", m.Changes)
+	}
+}
+
+func TestMigrateForwardNodeFilter(t *testing.T) {
+	node := &ForwardNodeConfig{
+		Name:     "node-0",
+		Protocol: "http",
+		Host:     "example.com",
+		Path:     "/api",
+		Filter: &NodeFilterConfig{
+			Host: "example.org",
+		},
+	}
+	if changes := migrateForwardNode(node); len(changes) == 0 {
+		t.Fatal("no change")
+	}
+
+	want := &NodeFilterConfig{
+		Protocol: "http",
+		Host:     "example.org",
+		Path:     "/api",
+	}
+	if !node.Filter.Equal(want) {
+		t.Errorf("filter %+v, want %+v", node.Filter, want)
+	}
+	if node.Protocol != "" || node.Host != "" || node.Path != "" {
+		t.Errorf("deprecated fields are kept")
+	}
+}
@@ -0,0 +1,228 @@
+package queue
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-gost/gostctl/config"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	queueDir = "queue"
+)
+
+type Op string
+
+const (
+	OpCreate Op = "create"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+// Change is an edit staged while its server was unreachable.
+type Change struct {
+	ID   string
+	Time time.Time
+	// Kind is the resource type of the object, e.g. service or chain.
+	Kind string
+	Op   Op
+	Name string
+	// Value is the JSON of the object to create or update, it is sealed in the queue file as it may hold credentials.
+	Value string `yaml:",omitempty"`
+	// Base is the hash of the remote object when the change was staged, empty if the object did not exist.
+	Base string `yaml:",omitempty"`
+	// Conflict is set if the remote object changed since the change was staged,
+	// the queue is not replayed further until it is resolved.
+	Conflict string `yaml:",omitempty"`
+	// Failed is the error of the server rejecting the change,
+	// the queue is not replayed further until the change is retried or discarded.
+	Failed string `yaml:",omitempty"`
+}
+
+var (
+	queues = make(map[string][]Change)
+	mu     sync.Mutex
+)
+
+// Add appends a change to the queue of server.
+func Add(server string, c Change) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c.ID == "" {
+		c.ID = uuid.NewString()
+	}
+	if c.Time.IsZero() {
+		c.Time = time.Now()
+	}
+
+	changes, err := load(server)
+	if err != nil {
+		return err
+	}
+	changes = append(slices.Clip(changes), c)
+	if err := write(server, changes); err != nil {
+		return err
+	}
+	queues[server] = changes
+
+	slog.With("kind", "queue", "server", server).Info(fmt.Sprintf("%s %s %s staged", c.Op, c.Kind, c.Name))
+	return nil
+}
+
+// List returns the changes queued for server, in order.
+func List(server string) []Change {
+	mu.Lock()
+	defer mu.Unlock()
+
+	changes, err := load(server)
+	if err != nil {
+		slog.With("kind", "queue", "server", server).Error(fmt.Sprintf("load queue: %v", err))
+	}
+	return slices.Clone(changes)
+}
+
+// Pending reports whether the named object has queued changes.
+func Pending(server string, kind string, name string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	changes, _ := load(server)
+	for _, c := range changes {
+		if c.Kind == kind && c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Update replaces the queued change with the same ID.
+func Update(server string, c Change) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	changes, err := load(server)
+	if err != nil {
+		return err
+	}
+	changes = slices.Clone(changes)
+	for i := range changes {
+		if changes[i].ID == c.ID {
+			changes[i] = c
+			if err := write(server, changes); err != nil {
+				return err
+			}
+			queues[server] = changes
+			return nil
+		}
+	}
+	return nil
+}
+
+// Remove deletes the change from the queue of server.
+func Remove(server string, id string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	changes, err := load(server)
+	if err != nil {
+		return err
+	}
+	changes = slices.DeleteFunc(slices.Clone(changes), func(c Change) bool {
+		return c.ID == id
+	})
+	if err := write(server, changes); err != nil {
+		return err
+	}
+	queues[server] = changes
+	return nil
+}
+
+// Hash returns the digest of the object v, or an empty string if v is nil.
+func Hash(v any) string {
+	if v == nil {
+		return ""
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return ""
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func filename(server string) string {
+	return filepath.Join(config.Dir(), queueDir, url.PathEscape(server)+".yml")
+}
+
+// load returns the cached queue of server, reading it from disk on first use.
+// The queue is not cached if it cannot be read, e.g. the secrets are locked, so that it is not overwritten.
+func load(server string) ([]Change, error) {
+	if changes, ok := queues[server]; ok {
+		return changes, nil
+	}
+
+	var changes []Change
+	b, err := os.ReadFile(filename(server))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(b, &changes); err != nil {
+			return nil, err
+		}
+	}
+	for i := range changes {
+		if changes[i].Value, err = config.Open(changes[i].Value); err != nil {
+			return nil, err
+		}
+	}
+	queues[server] = changes
+
+	return changes, nil
+}
+
+func write(server string, changes []Change) error {
+	name := filename(server)
+	if len(changes) == 0 {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	sealed := slices.Clone(changes)
+	for i := range sealed {
+		var err error
+		if sealed[i].Value, err = config.Seal(sealed[i].Value); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(sealed); err != nil {
+		return err
+	}
+	enc.Close()
+
+	return config.WriteFile(name, buf.Bytes(), 0600)
+}
@@ -0,0 +1,41 @@
+package runner
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultBackoffMin = 500 * time.Millisecond
+	defaultBackoffMax = 30 * time.Second
+)
+
+// Backoff computes the exponential delay before the next attempt of a failed task.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Duration returns the delay after n consecutive failures, n starts at 1.
+// The delay doubles with each failure up to Max, the second half of it is randomized
+// so that the tasks of several servers do not retry in lockstep.
+func (b Backoff) Duration(n int) time.Duration {
+	min, max := b.Min, b.Max
+	if min <= 0 {
+		min = defaultBackoffMin
+	}
+	if max < min {
+		max = min
+	}
+
+	d := min
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
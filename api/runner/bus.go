@@ -0,0 +1,127 @@
+package runner
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Filter selects the task events delivered to a subscription.
+type Filter func(e *TaskEvent) bool
+
+// FilterTask matches the events of the given tasks.
+func FilterTask(ids ...TaskID) Filter {
+	return func(e *TaskEvent) bool {
+		for _, id := range ids {
+			if e.TaskID == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterPrefix matches the events whose task ID starts with prefix, e.g. "task.api.service.".
+func FilterPrefix(prefix string) Filter {
+	return func(e *TaskEvent) bool {
+		return strings.HasPrefix(string(e.TaskID), prefix)
+	}
+}
+
+// FilterPlan matches the events of the plan and of its steps.
+func FilterPlan(id TaskID) Filter {
+	return func(e *TaskEvent) bool {
+		return e.TaskID == id || (e.Progress != nil && e.Progress.Plan == id)
+	}
+}
+
+// FilterResource matches the events of the tasks operating on the named resource of kind,
+// e.g. FilterResource("service", "service-0").
+func FilterResource(kind string, name string) Filter {
+	prefix := "task.api." + kind + "."
+	return func(e *TaskEvent) bool {
+		return e.Resource == name && strings.HasPrefix(string(e.TaskID), prefix)
+	}
+}
+
+// Subscription receives the task events matched by its filter.
+type Subscription struct {
+	bus    *Bus
+	filter Filter
+	events chan *TaskEvent
+	once   sync.Once
+}
+
+// Event returns the channel of the subscribed events.
+// The channel is closed after Unsubscribe.
+func (s *Subscription) Event() <-chan *TaskEvent {
+	return s.events
+}
+
+// Unsubscribe removes the subscription from the bus. It is safe to call it more than once.
+func (s *Subscription) Unsubscribe() {
+	if s == nil {
+		return
+	}
+	s.once.Do(func() {
+		s.bus.remove(s)
+	})
+}
+
+// Bus fans out task events to the subscribers.
+// Publishing never blocks, an event is dropped for the subscriber whose buffer is full.
+type Bus struct {
+	subs map[*Subscription]struct{}
+	mu   sync.RWMutex
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a subscription for the events matched by filter, a nil filter matches all events.
+func (b *Bus) Subscribe(filter Filter) *Subscription {
+	s := &Subscription{
+		bus:    b,
+		filter: filter,
+		events: make(chan *TaskEvent, 16),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs[s] = struct{}{}
+
+	return s
+}
+
+func (b *Bus) Publish(e *TaskEvent) {
+	if e == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for s := range b.subs {
+		if s.filter != nil && !s.filter(e) {
+			continue
+		}
+
+		select {
+		case s.events <- e:
+		default:
+			slog.With("kind", "runner").Warn("event dropped, subscriber is full", "task", e.TaskID)
+		}
+	}
+}
+
+func (b *Bus) remove(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, s)
+	close(s.events)
+}
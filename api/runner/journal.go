@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	journalSize = 256
+)
+
+// Summarizer is implemented by the tasks which can describe the payload they send.
+type Summarizer interface {
+	Summary() string
+}
+
+// JournalEntry records an execution of a task.
+type JournalEntry struct {
+	// Seq identifies the entry, it increases with each execution.
+	Seq      uint64
+	TaskID   TaskID
+	Resource string
+	Server   string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+	Summary  string
+	// Plan is the plan the task is a step of, if any.
+	Plan TaskID
+
+	task Task
+}
+
+// Task returns the task of the entry, it can be executed again.
+func (e *JournalEntry) Task() Task {
+	return e.task
+}
+
+// TaskJournal keeps the most recent task executions.
+type TaskJournal struct {
+	entries []JournalEntry
+	seq     uint64
+	size    int
+	mu      sync.RWMutex
+}
+
+func NewTaskJournal(size int) *TaskJournal {
+	return &TaskJournal{
+		size: size,
+	}
+}
+
+func (j *TaskJournal) Add(entry JournalEntry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.seq++
+	entry.Seq = j.seq
+
+	j.entries = append(j.entries, entry)
+	if n := len(j.entries) - j.size; n > 0 {
+		j.entries = append(j.entries[:0], j.entries[n:]...)
+	}
+}
+
+// Entries returns the entries of the journal, the most recent first.
+func (j *TaskJournal) Entries() []JournalEntry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	entries := make([]JournalEntry, 0, len(j.entries))
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		entries = append(entries, j.entries[i])
+	}
+	return entries
+}
+
+func (r *Runner) record(task Task, plan TaskID, start time.Time, err error) {
+	entry := JournalEntry{
+		TaskID:   task.ID(),
+		Server:   r.Server(),
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+		Plan:     plan,
+		task:     task,
+	}
+	if t, ok := task.(ResourceTask); ok {
+		entry.Resource = t.Resource()
+	}
+	if t, ok := task.(Summarizer); ok {
+		entry.Summary = t.Summary()
+	}
+
+	r.journal.Add(entry)
+}
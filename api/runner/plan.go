@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Step is a task of a plan together with the task that reverts it.
+type Step struct {
+	Task Task
+	// Undo reverts the effect of Task when a later step of the plan fails, it can be nil.
+	Undo Task
+}
+
+// Progress describes the step of a plan a task event belongs to.
+type Progress struct {
+	Plan  TaskID
+	Step  int
+	Steps int
+	// Rollback reports whether the event comes from an undo task.
+	Rollback bool
+}
+
+// PlanError is returned by a plan when one of its steps fails.
+type PlanError struct {
+	Step int
+	Err  error
+	// RollbackErr joins the errors of the undo tasks which failed during the rollback.
+	RollbackErr error
+}
+
+func (e *PlanError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("step %d: %v (rollback: %v)", e.Step+1, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("step %d: %v", e.Step+1, e.Err)
+}
+
+func (e *PlanError) Unwrap() error {
+	return e.Err
+}
+
+// Plan is a task running an ordered list of steps as a whole.
+// If a step fails, the undo tasks of the completed steps are run in reverse order.
+type Plan struct {
+	id    TaskID
+	steps []Step
+}
+
+func NewPlan(id TaskID, steps ...Step) *Plan {
+	return &Plan{
+		id:    id,
+		steps: steps,
+	}
+}
+
+// Add appends a step to the plan.
+func (p *Plan) Add(task Task, undo Task) *Plan {
+	p.steps = append(p.steps, Step{Task: task, Undo: undo})
+	return p
+}
+
+func (p *Plan) ID() TaskID {
+	return p.id
+}
+
+func (p *Plan) Run(ctx context.Context) error {
+	r := runnerFromContext(ctx)
+	log := slog.With("kind", "plan", "plan", p.id)
+
+	for i, step := range p.steps {
+		if step.Task == nil {
+			continue
+		}
+
+		start := time.Now()
+		err := runTask(ctx, step.Task)
+		r.progress(step.Task, &Progress{Plan: p.id, Step: i, Steps: len(p.steps)}, start, err)
+		if err == nil {
+			continue
+		}
+
+		log.ErrorContext(ctx, fmt.Sprintf("step %d %s: %v, rollback", i+1, step.Task.ID(), err))
+
+		// the rollback must complete even if the plan is cancelled.
+		undoCtx := context.WithoutCancel(ctx)
+
+		var errs []error
+		for j := i - 1; j >= 0; j-- {
+			undo := p.steps[j].Undo
+			if undo == nil {
+				continue
+			}
+			start := time.Now()
+			uerr := runTask(undoCtx, undo)
+			r.progress(undo, &Progress{Plan: p.id, Step: j, Steps: len(p.steps), Rollback: true}, start, uerr)
+			if uerr != nil {
+				log.ErrorContext(ctx, fmt.Sprintf("undo step %d %s: %v", j+1, undo.ID(), uerr))
+				errs = append(errs, uerr)
+			}
+		}
+
+		return &PlanError{
+			Step:        i,
+			Err:         err,
+			RollbackErr: errors.Join(errs...),
+		}
+	}
+
+	return nil
+}
+
+type runnerKey struct{}
+
+// runnerContext is attached to the context of a running task,
+// so that a plan can report the progress of its steps.
+type runnerContext struct {
+	runner  *Runner
+	options *Options
+}
+
+func contextWithRunner(ctx context.Context, r *Runner, options *Options) context.Context {
+	return context.WithValue(ctx, runnerKey{}, &runnerContext{
+		runner:  r,
+		options: options,
+	})
+}
+
+func runnerFromContext(ctx context.Context) *runnerContext {
+	rc, _ := ctx.Value(runnerKey{}).(*runnerContext)
+	return rc
+}
+
+func (rc *runnerContext) progress(task Task, progress *Progress, start time.Time, err error) {
+	if rc == nil {
+		return
+	}
+	rc.runner.record(task, progress.Plan, start, err)
+	rc.runner.publish(task, rc.options, progress, err)
+}
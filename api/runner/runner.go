@@ -2,8 +2,10 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"sync"
 	"time"
 )
@@ -14,18 +16,41 @@ var (
 
 type TaskEvent struct {
 	TaskID TaskID
-	Err    error
+	// Resource is the name of the object the task operates on, if any.
+	Resource string
+	Err      error
+	// Inline reports whether the result is shown by the page running the task,
+	// instead of a global notification.
+	Inline bool
+	// Progress is set if the task is a step of a plan.
+	Progress *Progress
 }
 
+var (
+	ErrShutdown = errors.New("runner is shut down")
+)
+
 type taskState struct {
 	task   Task
 	cancel context.CancelFunc
+	// periodic reports whether the task runs at an interval.
+	periodic bool
 }
 
 type Options struct {
 	Async    bool
 	Interval time.Duration
 	Cancel   bool
+	Inline   bool
+	// Retry is the number of times a failed run is retried.
+	Retry int
+	// Backoff is the delay between the retries. For a periodic task,
+	// it also replaces the interval while the task keeps failing.
+	Backoff *Backoff
+	// Timeout is the deadline of each run, retries included.
+	Timeout time.Duration
+	// Schedule runs the task at the times of a cron expression instead of at an interval.
+	Schedule *Cron
 }
 
 type Option func(opts *Options)
@@ -48,8 +73,40 @@ func WithCancel(cancel bool) Option {
 	}
 }
 
-func Event() <-chan *TaskEvent {
-	return runner.Event()
+func WithRetry(retry int) Option {
+	return func(opts *Options) {
+		opts.Retry = retry
+	}
+}
+
+func WithBackoff(min, max time.Duration) Option {
+	return func(opts *Options) {
+		opts.Backoff = &Backoff{Min: min, Max: max}
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.Timeout = timeout
+	}
+}
+
+func WithSchedule(schedule *Cron) Option {
+	return func(opts *Options) {
+		opts.Schedule = schedule
+	}
+}
+
+func WithInline(inline bool) Option {
+	return func(opts *Options) {
+		opts.Inline = inline
+	}
+}
+
+// Subscribe registers a subscription to the task events of the default runner.
+// The subscriber must call Unsubscribe when it is no longer interested, e.g. on page exit.
+func Subscribe(filter Filter) *Subscription {
+	return runner.Subscribe(filter)
 }
 
 func Exec(ctx context.Context, task Task, opts ...Option) error {
@@ -60,21 +117,78 @@ func Cancel(id TaskID) {
 	runner.Cancel(id)
 }
 
+// Shutdown stops the default runner, see Runner.Shutdown.
+func Shutdown(ctx context.Context) error {
+	return runner.Shutdown(ctx)
+}
+
+// SetServer sets the name of the server the tasks of the default runner are sent to.
+func SetServer(server string) {
+	runner.SetServer(server)
+}
+
+// Server returns the name of the server the tasks of the default runner are sent to.
+func Server() string {
+	return runner.Server()
+}
+
+// Journal returns the recent task executions of the default runner, the most recent first.
+func Journal() []JournalEntry {
+	return runner.Journal()
+}
+
 type Runner struct {
-	events chan *TaskEvent
-	states map[TaskID]taskState
-	mu     sync.RWMutex
+	bus     *Bus
+	journal *TaskJournal
+	server  string
+	states  map[TaskID]taskState
+	closed  bool
+	// wg tracks the tasks in flight.
+	wg sync.WaitGroup
+	mu sync.RWMutex
 }
 
 func NewRunner() *Runner {
 	return &Runner{
-		events: make(chan *TaskEvent, 16),
-		states: make(map[TaskID]taskState),
+		bus:     NewBus(),
+		journal: NewTaskJournal(journalSize),
+		states:  make(map[TaskID]taskState),
 	}
 }
 
-func (r *Runner) Event() <-chan *TaskEvent {
-	return r.events
+func (r *Runner) SetServer(server string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.server = server
+}
+
+func (r *Runner) Server() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.server
+}
+
+func (r *Runner) Journal() []JournalEntry {
+	return r.journal.Entries()
+}
+
+func (r *Runner) Subscribe(filter Filter) *Subscription {
+	return r.bus.Subscribe(filter)
+}
+
+func (r *Runner) publish(task Task, options *Options, progress *Progress, err error) {
+	e := &TaskEvent{
+		TaskID:   task.ID(),
+		Err:      err,
+		Inline:   options.Inline,
+		Progress: progress,
+	}
+	if t, ok := task.(ResourceTask); ok {
+		e.Resource = t.Resource()
+	}
+	r.bus.Publish(e)
 }
 
 func (r *Runner) Exec(ctx context.Context, task Task, opts ...Option) error {
@@ -91,67 +205,70 @@ func (r *Runner) Exec(ctx context.Context, task Task, opts ...Option) error {
 		r.Cancel(task.ID())
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	r.setState(taskState{
-		task:   task,
-		cancel: cancel,
-	})
+	ctx, cancel := context.WithCancel(contextWithRunner(ctx, r, &options))
+	if err := r.start(taskState{
+		task:     task,
+		cancel:   cancel,
+		periodic: options.Interval > 0 || options.Schedule != nil,
+	}); err != nil {
+		cancel()
+		return err
+	}
 
 	log := slog.With("kind", "runner", "async", options.Async)
 	log.DebugContext(ctx, fmt.Sprintf("task %s started", task.ID()))
 
 	if !options.Async {
+		defer r.wg.Done()
+
 		t := time.Now()
-		err := task.Run(ctx)
+		err := r.run(ctx, task, &options)
 
 		log.With("duration", time.Since(t)).DebugContext(ctx, fmt.Sprintf("task %s done: %v", task.ID(), err))
 
-		select {
-		case <-ctx.Done():
-		default:
-			r.events <- &TaskEvent{
-				TaskID: task.ID(),
-				Err:    err,
-			}
-		}
+		r.done(ctx, task, &options, err)
 
 		return err
 	}
 
 	go func() {
+		defer r.wg.Done()
 		defer cancel()
 
+		// failures counts the consecutive failed runs of a periodic task.
+		var failures int
+
 		t := time.Now()
 		defer func() {
 			log.With("duration", time.Since(t)).DebugContext(ctx, fmt.Sprintf("task %s done", task.ID()))
 		}()
 
-		run := func() {
-			err := task.Run(ctx)
-			select {
-			case <-ctx.Done():
-			default:
-				r.events <- &TaskEvent{
-					TaskID: task.ID(),
-					Err:    err,
-				}
-			}
+		run := func() error {
+			err := r.run(ctx, task, &options)
+			r.done(ctx, task, &options, err)
+			return err
 		}
 
-		run()
+		if options.Schedule != nil {
+			r.schedule(ctx, options.Schedule, run)
+			return
+		}
+
+		err := run()
 
 		interval := options.Interval
 		if interval <= 0 {
 			return
 		}
 
-		ticker := time.NewTicker(interval)
-		defer ticker.Stop()
+		timer := time.NewTimer(r.nextDelay(interval, options.Backoff, err, &failures))
+		defer timer.Stop()
 
 		for {
 			select {
-			case <-ticker.C:
-				run()
+			case <-timer.C:
+				err = run()
+				timer.Reset(r.nextDelay(interval, options.Backoff, err, &failures))
 			case <-ctx.Done():
 				return
 			}
@@ -161,19 +278,154 @@ func (r *Runner) Exec(ctx context.Context, task Task, opts ...Option) error {
 	return nil
 }
 
+// done publishes the result of a run of the task. The cancellation of a periodic task is not published,
+// while the cancellation of a one-off task is, as its subscribers wait for its result, e.g. a pending save.
+func (r *Runner) done(ctx context.Context, task Task, options *Options, err error) {
+	if ctx.Err() != nil {
+		if options.Interval > 0 || options.Schedule != nil {
+			return
+		}
+		err = ctx.Err()
+	}
+	r.publish(task, options, nil, err)
+}
+
+// schedule calls run at each time of the cron expression until ctx is done.
+func (r *Runner) schedule(ctx context.Context, cron *Cron, run func() error) {
+	for {
+		next := cron.Next(time.Now())
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			run()
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// run runs the task once, retrying it on failure as allowed by options.
+func (r *Runner) run(ctx context.Context, task Task, options *Options) (err error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	backoff := Backoff{}
+	if options.Backoff != nil {
+		backoff = *options.Backoff
+	}
+
+	// the periodic tasks, such as polling the config, would flood the journal.
+	if options.Interval <= 0 {
+		start := time.Now()
+		defer func() {
+			r.record(task, "", start, err)
+		}()
+	}
+
+	for n := 0; ; n++ {
+		if err = runTask(ctx, task); err == nil || n >= options.Retry {
+			return
+		}
+
+		d := backoff.Duration(n + 1)
+		slog.With("kind", "runner").DebugContext(ctx, fmt.Sprintf("task %s failed: %v, retry in %s", task.ID(), err, d))
+
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nextDelay returns the delay before the next run of a periodic task.
+// The task runs at interval while it succeeds, and backs off while it keeps failing.
+func (r *Runner) nextDelay(interval time.Duration, backoff *Backoff, err error, failures *int) time.Duration {
+	if err == nil || backoff == nil {
+		*failures = 0
+		return interval
+	}
+
+	*failures++
+	if d := backoff.Duration(*failures); d > interval {
+		return d
+	}
+	return interval
+}
+
+// runTask runs the task, a panic in it is returned as an error.
+func runTask(ctx context.Context, task Task) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("task %s panic: %v", task.ID(), v)
+			slog.With("kind", "runner").ErrorContext(ctx, err.Error(), "stack", string(debug.Stack()))
+		}
+	}()
+
+	return task.Run(ctx)
+}
+
 func (r *Runner) Cancel(id TaskID) {
 	r.delState(id)
 }
 
-func (r *Runner) setState(state taskState) {
-	if state.task == nil {
-		return
+// Shutdown stops the runner from accepting new tasks and cancels the periodic tasks.
+// It waits for the tasks in flight, such as a pending save, to complete until ctx is done,
+// then cancels the remaining tasks.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	for id, state := range r.states {
+		if state.periodic {
+			state.cancel()
+			delete(r.states, id)
+		}
+	}
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
 	}
 
+	r.mu.Lock()
+	for id, state := range r.states {
+		state.cancel()
+		delete(r.states, id)
+	}
+	r.mu.Unlock()
+
+	return ctx.Err()
+}
+
+// start registers the state of a task going to run.
+func (r *Runner) start(state taskState) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.closed {
+		return ErrShutdown
+	}
+
+	r.wg.Add(1)
 	r.states[state.task.ID()] = state
+
+	return nil
 }
 
 func (r *Runner) delState(id TaskID) {
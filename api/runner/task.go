@@ -53,9 +53,22 @@ const (
 	TaskCreateRecorder TaskID = "task.api.recorder.create"
 	TaskUpdateRecorder TaskID = "task.api.recorder.update"
 	TaskDeleteRecorder TaskID = "task.api.recorder.delete"
+
+	TaskPlanMigration TaskID = "task.plan.migration"
+
+	TaskReplayQueue TaskID = "task.queue.replay"
+
+	// TaskSchedule is the prefix of the IDs of the scheduled tasks, followed by the schedule name.
+	TaskSchedule TaskID = "task.schedule."
 )
 
 type Task interface {
 	ID() TaskID
 	Run(ctx context.Context) error
 }
+
+// ResourceTask is a task operating on a single named object, such as a service or a chain.
+type ResourceTask interface {
+	Task
+	Resource() string
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createAdmissionTask) ID() runner.TaskID {
 	return runner.TaskCreateAdmission
 }
 
+func (t *createAdmissionTask) Resource() string {
+	if t.admission == nil {
+		return ""
+	}
+	return t.admission.Name
+}
+
+func (t *createAdmissionTask) Summary() string {
+	return summary(t.admission)
+}
+
 func (t *createAdmissionTask) Run(ctx context.Context) (err error) {
 	if t.admission == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "admission", queue.OpCreate, t.admission.Name, t.admission); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create admission %s: %v", t.admission.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateAdmissionTask) ID() runner.TaskID {
 	return runner.TaskUpdateAdmission
 }
 
+func (t *updateAdmissionTask) Resource() string {
+	if t.admission == nil {
+		return ""
+	}
+	return t.admission.Name
+}
+
+func (t *updateAdmissionTask) Summary() string {
+	return summary(t.admission)
+}
+
 func (t *updateAdmissionTask) Run(ctx context.Context) (err error) {
 	if t.admission == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "admission", queue.OpUpdate, t.admission.Name, t.admission); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update admission %s: %v", t.admission.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteAdmissionTask) ID() runner.TaskID {
 	return runner.TaskDeleteAdmission
 }
 
+func (t *deleteAdmissionTask) Resource() string {
+	return t.admission
+}
+
+func (t *deleteAdmissionTask) Summary() string {
+	return t.admission
+}
+
 func (t *deleteAdmissionTask) Run(ctx context.Context) (err error) {
 	if t.admission == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "admission", queue.OpDelete, t.admission, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete admission %s: %v", t.admission, err))
 	}()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createAutherTask) ID() runner.TaskID {
 	return runner.TaskCreateAuther
 }
 
+func (t *createAutherTask) Resource() string {
+	if t.auther == nil {
+		return ""
+	}
+	return t.auther.Name
+}
+
+func (t *createAutherTask) Summary() string {
+	return summary(t.auther)
+}
+
 func (t *createAutherTask) Run(ctx context.Context) (err error) {
 	if t.auther == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "auther", queue.OpCreate, t.auther.Name, t.auther); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create auther %s: %v", t.auther.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateAutherTask) ID() runner.TaskID {
 	return runner.TaskUpdateAuther
 }
 
+func (t *updateAutherTask) Resource() string {
+	if t.auther == nil {
+		return ""
+	}
+	return t.auther.Name
+}
+
+func (t *updateAutherTask) Summary() string {
+	return summary(t.auther)
+}
+
 func (t *updateAutherTask) Run(ctx context.Context) (err error) {
 	if t.auther == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "auther", queue.OpUpdate, t.auther.Name, t.auther); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update auther %s: %v", t.auther.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteAutherTask) ID() runner.TaskID {
 	return runner.TaskDeleteAuther
 }
 
+func (t *deleteAutherTask) Resource() string {
+	return t.auther
+}
+
+func (t *deleteAutherTask) Summary() string {
+	return t.auther
+}
+
 func (t *deleteAutherTask) Run(ctx context.Context) (err error) {
 	if t.auther == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "auther", queue.OpDelete, t.auther, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete auther %s: %v", t.auther, err))
 	}()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createBypassTask) ID() runner.TaskID {
 	return runner.TaskCreateBypass
 }
 
+func (t *createBypassTask) Resource() string {
+	if t.bypass == nil {
+		return ""
+	}
+	return t.bypass.Name
+}
+
+func (t *createBypassTask) Summary() string {
+	return summary(t.bypass)
+}
+
 func (t *createBypassTask) Run(ctx context.Context) (err error) {
 	if t.bypass == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "bypass", queue.OpCreate, t.bypass.Name, t.bypass); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create bypass %s: %v", t.bypass.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateBypassTask) ID() runner.TaskID {
 	return runner.TaskUpdateBypass
 }
 
+func (t *updateBypassTask) Resource() string {
+	if t.bypass == nil {
+		return ""
+	}
+	return t.bypass.Name
+}
+
+func (t *updateBypassTask) Summary() string {
+	return summary(t.bypass)
+}
+
 func (t *updateBypassTask) Run(ctx context.Context) (err error) {
 	if t.bypass == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "bypass", queue.OpUpdate, t.bypass.Name, t.bypass); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update bypass %s: %v", t.bypass.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteBypassTask) ID() runner.TaskID {
 	return runner.TaskDeleteBypass
 }
 
+func (t *deleteBypassTask) Resource() string {
+	return t.bypass
+}
+
+func (t *deleteBypassTask) Summary() string {
+	return t.bypass
+}
+
 func (t *deleteBypassTask) Run(ctx context.Context) (err error) {
 	if t.bypass == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "bypass", queue.OpDelete, t.bypass, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete bypass %s: %v", t.bypass, err))
 	}()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createChainTask) ID() runner.TaskID {
 	return runner.TaskCreateChain
 }
 
+func (t *createChainTask) Resource() string {
+	if t.chain == nil {
+		return ""
+	}
+	return t.chain.Name
+}
+
+func (t *createChainTask) Summary() string {
+	return summary(t.chain)
+}
+
 func (t *createChainTask) Run(ctx context.Context) (err error) {
 	if t.chain == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "chain", queue.OpCreate, t.chain.Name, t.chain); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create chain %s: %v", t.chain.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateChainTask) ID() runner.TaskID {
 	return runner.TaskUpdateChain
 }
 
+func (t *updateChainTask) Resource() string {
+	if t.chain == nil {
+		return ""
+	}
+	return t.chain.Name
+}
+
+func (t *updateChainTask) Summary() string {
+	return summary(t.chain)
+}
+
 func (t *updateChainTask) Run(ctx context.Context) (err error) {
 	if t.chain == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "chain", queue.OpUpdate, t.chain.Name, t.chain); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update chain %s: %v", t.chain.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteChainTask) ID() runner.TaskID {
 	return runner.TaskDeleteChain
 }
 
+func (t *deleteChainTask) Resource() string {
+	return t.chain
+}
+
+func (t *deleteChainTask) Summary() string {
+	return t.chain
+}
+
 func (t *deleteChainTask) Run(ctx context.Context) (err error) {
 	if t.chain == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "chain", queue.OpDelete, t.chain, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete chain %s: %v", t.chain, err))
 	}()
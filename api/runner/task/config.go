@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -11,7 +12,15 @@ import (
 	"github.com/go-gost/gostctl/api/runner"
 )
 
-type getConfigTask struct{}
+const (
+	// fullPollEvery is the number of polls between two fetches of the full config,
+	// the polls in between fetch the status of the services only, if the server supports the status view.
+	fullPollEvery = 10
+)
+
+type getConfigTask struct {
+	polls int
+}
 
 func GetConfig() runner.Task {
 	return &getConfigTask{}
@@ -22,60 +31,128 @@ func (t *getConfigTask) ID() runner.TaskID {
 }
 
 func (t *getConfigTask) Run(ctx context.Context) error {
-	cfg, err := client.Default().GetConfig(ctx)
+	c := client.Default()
+	oldCfg := api.GetConfig()
+
+	t.polls++
+	if (t.polls-1)%fullPollEvery != 0 && !c.Dirty() {
+		status, err := c.GetServiceStatus(ctx)
+		switch {
+		case errors.Is(err, client.ErrStatusViewUnsupported):
+			// the full config is fetched on each poll.
+		case err != nil:
+			return err
+		case setStatus(oldCfg, status):
+			return nil
+		}
+	}
+
+	cfg, modified, err := c.GetConfigIfModified(ctx)
 	if err != nil {
 		return err
 	}
+	t.polls = 1
+	if cfg == nil {
+		return nil
+	}
 
-	oldCfg := api.GetConfig()
+	// only the status changes, the rest of the config is kept.
+	if !modified && setStatus(oldCfg, serviceStatus(cfg)) {
+		return nil
+	}
 
 	for _, service := range cfg.Services {
 		if service.Status == nil {
 			service.Status = &api.ServiceStatus{}
 		}
-		if service.Status.Stats == nil {
-			continue
-		}
+		updateStats(service, oldCfg.Services)
+	}
+
+	api.SetConfig(cfg)
+	return nil
+}
+
+func serviceStatus(cfg *api.Config) map[string]*api.ServiceStatus {
+	status := make(map[string]*api.ServiceStatus, len(cfg.Services))
+	for _, svc := range cfg.Services {
+		status[svc.Name] = svc.Status
+	}
+	return status
+}
+
+// setStatus updates the status of the services in the current config.
+// It reports false if the services have been added or removed, the full config is needed then.
+func setStatus(oldCfg *api.Config, status map[string]*api.ServiceStatus) bool {
+	if len(status) != len(oldCfg.Services) {
+		return false
+	}
+
+	cfg := &api.Config{}
+	*cfg = *oldCfg
+	cfg.Services = make([]*api.ServiceConfig, 0, len(oldCfg.Services))
 
-		service.Status.Stats.Time = time.Now()
-
-		for _, svc := range oldCfg.Services {
-			if svc.Name != service.Name ||
-				svc.Status == nil ||
-				svc.Status.Stats == nil ||
-				svc.Status.CreateTime != service.Status.CreateTime {
-				continue
-			}
-
-			d := service.Status.Stats.Time.Sub(svc.Status.Stats.Time)
-			if d <= 0 {
-				continue
-			}
-
-			inputRateBytes := int64(service.Status.Stats.InputBytes) - int64(svc.Status.Stats.InputBytes)
-			if inputRateBytes < 0 {
-				inputRateBytes = 0
-			}
-			service.Status.Stats.InputRateBytes = uint64(float64(inputRateBytes) / d.Seconds())
-
-			outputRateBytes := int64(service.Status.Stats.OutputBytes) - int64(svc.Status.Stats.OutputBytes)
-			if outputRateBytes < 0 {
-				outputRateBytes = 0
-			}
-			service.Status.Stats.OutputRateBytes = uint64(float64(outputRateBytes) / d.Seconds())
-
-			reqRate := int64(service.Status.Stats.TotalConns) - int64(svc.Status.Stats.TotalConns)
-			if reqRate < 0 {
-				reqRate = 0
-			}
-			service.Status.Stats.RequestRate = float64(reqRate) / d.Seconds()
-
-			break
+	for _, svc := range oldCfg.Services {
+		st, ok := status[svc.Name]
+		if !ok {
+			return false
 		}
+		if st == nil {
+			st = &api.ServiceStatus{}
+		}
+
+		// only the status changes, the rest of the service is shared with the current config.
+		service := &api.ServiceConfig{}
+		*service = *svc
+		service.Status = st
+		updateStats(service, oldCfg.Services)
+		cfg.Services = append(cfg.Services, service)
 	}
 
 	api.SetConfig(cfg)
-	return nil
+	return true
+}
+
+// updateStats calculates the rates of the service stats from the previous stats of the service.
+func updateStats(service *api.ServiceConfig, oldServices []*api.ServiceConfig) {
+	if service.Status.Stats == nil {
+		return
+	}
+
+	service.Status.Stats.Time = time.Now()
+
+	for _, svc := range oldServices {
+		if svc.Name != service.Name ||
+			svc.Status == nil ||
+			svc.Status.Stats == nil ||
+			svc.Status.CreateTime != service.Status.CreateTime {
+			continue
+		}
+
+		d := service.Status.Stats.Time.Sub(svc.Status.Stats.Time)
+		if d <= 0 {
+			continue
+		}
+
+		inputRateBytes := int64(service.Status.Stats.InputBytes) - int64(svc.Status.Stats.InputBytes)
+		if inputRateBytes < 0 {
+			inputRateBytes = 0
+		}
+		service.Status.Stats.InputRateBytes = uint64(float64(inputRateBytes) / d.Seconds())
+
+		outputRateBytes := int64(service.Status.Stats.OutputBytes) - int64(svc.Status.Stats.OutputBytes)
+		if outputRateBytes < 0 {
+			outputRateBytes = 0
+		}
+		service.Status.Stats.OutputRateBytes = uint64(float64(outputRateBytes) / d.Seconds())
+
+		reqRate := int64(service.Status.Stats.TotalConns) - int64(svc.Status.Stats.TotalConns)
+		if reqRate < 0 {
+			reqRate = 0
+		}
+		service.Status.Stats.RequestRate = float64(reqRate) / d.Seconds()
+
+		break
+	}
 }
 
 type saveConfigTask struct {
@@ -92,6 +169,10 @@ func (t *saveConfigTask) ID() runner.TaskID {
 	return runner.TaskSaveConfig
 }
 
+func (t *saveConfigTask) Summary() string {
+	return t.path
+}
+
 func (t *saveConfigTask) Run(ctx context.Context) (err error) {
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("save config to %s: %v", t.path, err))
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createHopTask) ID() runner.TaskID {
 	return runner.TaskCreateHop
 }
 
+func (t *createHopTask) Resource() string {
+	if t.hop == nil {
+		return ""
+	}
+	return t.hop.Name
+}
+
+func (t *createHopTask) Summary() string {
+	return summary(t.hop)
+}
+
 func (t *createHopTask) Run(ctx context.Context) (err error) {
 	if t.hop == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "hop", queue.OpCreate, t.hop.Name, t.hop); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create hop %s: %v", t.hop.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateHopTask) ID() runner.TaskID {
 	return runner.TaskUpdateHop
 }
 
+func (t *updateHopTask) Resource() string {
+	if t.hop == nil {
+		return ""
+	}
+	return t.hop.Name
+}
+
+func (t *updateHopTask) Summary() string {
+	return summary(t.hop)
+}
+
 func (t *updateHopTask) Run(ctx context.Context) (err error) {
 	if t.hop == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "hop", queue.OpUpdate, t.hop.Name, t.hop); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update hop %s: %v", t.hop.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteHopTask) ID() runner.TaskID {
 	return runner.TaskDeleteHop
 }
 
+func (t *deleteHopTask) Resource() string {
+	return t.hop
+}
+
+func (t *deleteHopTask) Summary() string {
+	return t.hop
+}
+
 func (t *deleteHopTask) Run(ctx context.Context) (err error) {
 	if t.hop == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "hop", queue.OpDelete, t.hop, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete hop %s: %v", t.hop, err))
 	}()
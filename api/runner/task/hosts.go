@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createHostMapperTask) ID() runner.TaskID {
 	return runner.TaskCreateHosts
 }
 
+func (t *createHostMapperTask) Resource() string {
+	if t.hostMapper == nil {
+		return ""
+	}
+	return t.hostMapper.Name
+}
+
+func (t *createHostMapperTask) Summary() string {
+	return summary(t.hostMapper)
+}
+
 func (t *createHostMapperTask) Run(ctx context.Context) (err error) {
 	if t.hostMapper == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "hosts", queue.OpCreate, t.hostMapper.Name, t.hostMapper); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create hosts %s: %v", t.hostMapper.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateHostMapperTask) ID() runner.TaskID {
 	return runner.TaskUpdateHosts
 }
 
+func (t *updateHostMapperTask) Resource() string {
+	if t.hostMapper == nil {
+		return ""
+	}
+	return t.hostMapper.Name
+}
+
+func (t *updateHostMapperTask) Summary() string {
+	return summary(t.hostMapper)
+}
+
 func (t *updateHostMapperTask) Run(ctx context.Context) (err error) {
 	if t.hostMapper == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "hosts", queue.OpUpdate, t.hostMapper.Name, t.hostMapper); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update hosts %s: %v", t.hostMapper.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteHostMapperTask) ID() runner.TaskID {
 	return runner.TaskDeleteHosts
 }
 
+func (t *deleteHostMapperTask) Resource() string {
+	return t.hostMapper
+}
+
+func (t *deleteHostMapperTask) Summary() string {
+	return t.hostMapper
+}
+
 func (t *deleteHostMapperTask) Run(ctx context.Context) (err error) {
 	if t.hostMapper == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "hosts", queue.OpDelete, t.hostMapper, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete hosts %s: %v", t.hostMapper, err))
 	}()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createLimiterTask) ID() runner.TaskID {
 	return runner.TaskCreateLimiter
 }
 
+func (t *createLimiterTask) Resource() string {
+	if t.limiter == nil {
+		return ""
+	}
+	return t.limiter.Name
+}
+
+func (t *createLimiterTask) Summary() string {
+	return summary(t.limiter)
+}
+
 func (t *createLimiterTask) Run(ctx context.Context) (err error) {
 	if t.limiter == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "limiter", queue.OpCreate, t.limiter.Name, t.limiter); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create limiter %s: %v", t.limiter.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateLimiterTask) ID() runner.TaskID {
 	return runner.TaskUpdateLimiter
 }
 
+func (t *updateLimiterTask) Resource() string {
+	if t.limiter == nil {
+		return ""
+	}
+	return t.limiter.Name
+}
+
+func (t *updateLimiterTask) Summary() string {
+	return summary(t.limiter)
+}
+
 func (t *updateLimiterTask) Run(ctx context.Context) (err error) {
 	if t.limiter == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "limiter", queue.OpUpdate, t.limiter.Name, t.limiter); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update limiter %s: %v", t.limiter.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteLimiterTask) ID() runner.TaskID {
 	return runner.TaskDeleteLimiter
 }
 
+func (t *deleteLimiterTask) Resource() string {
+	return t.limiter
+}
+
+func (t *deleteLimiterTask) Summary() string {
+	return t.limiter
+}
+
 func (t *deleteLimiterTask) Run(ctx context.Context) (err error) {
 	if t.limiter == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "limiter", queue.OpDelete, t.limiter, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete limiter %s: %v", t.limiter, err))
 	}()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createObserverTask) ID() runner.TaskID {
 	return runner.TaskCreateObserver
 }
 
+func (t *createObserverTask) Resource() string {
+	if t.observer == nil {
+		return ""
+	}
+	return t.observer.Name
+}
+
+func (t *createObserverTask) Summary() string {
+	return summary(t.observer)
+}
+
 func (t *createObserverTask) Run(ctx context.Context) (err error) {
 	if t.observer == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "observer", queue.OpCreate, t.observer.Name, t.observer); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create observer %s: %v", t.observer.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateObserverTask) ID() runner.TaskID {
 	return runner.TaskUpdateObserver
 }
 
+func (t *updateObserverTask) Resource() string {
+	if t.observer == nil {
+		return ""
+	}
+	return t.observer.Name
+}
+
+func (t *updateObserverTask) Summary() string {
+	return summary(t.observer)
+}
+
 func (t *updateObserverTask) Run(ctx context.Context) (err error) {
 	if t.observer == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "observer", queue.OpUpdate, t.observer.Name, t.observer); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update observer %s: %v", t.observer.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteObserverTask) ID() runner.TaskID {
 	return runner.TaskDeleteObserver
 }
 
+func (t *deleteObserverTask) Resource() string {
+	return t.observer
+}
+
+func (t *deleteObserverTask) Summary() string {
+	return t.observer
+}
+
 func (t *deleteObserverTask) Run(ctx context.Context) (err error) {
 	if t.observer == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "observer", queue.OpDelete, t.observer, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete observer %s: %v", t.observer, err))
 	}()
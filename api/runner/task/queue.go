@@ -0,0 +1,343 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/api/queue"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/config"
+)
+
+// lookup returns the named object of kind in the current config, or nil if it does not exist.
+func lookup(kind string, name string) any {
+	return lookupIn(api.GetConfig(), kind, name)
+}
+
+func lookupIn(cfg *api.Config, kind string, name string) any {
+	if cfg == nil {
+		return nil
+	}
+
+	switch kind {
+	case "service":
+		v := findObject(cfg.Services, name, func(v *api.ServiceConfig) string { return v.Name })
+		if v == nil {
+			return nil
+		}
+		v = v.Copy()
+		// status is read-only and changes all the time.
+		v.Status = nil
+		return v
+	case "chain":
+		if v := findObject(cfg.Chains, name, func(v *api.ChainConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "hop":
+		if v := findObject(cfg.Hops, name, func(v *api.HopConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "auther":
+		if v := findObject(cfg.Authers, name, func(v *api.AutherConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "admission":
+		if v := findObject(cfg.Admissions, name, func(v *api.AdmissionConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "bypass":
+		if v := findObject(cfg.Bypasses, name, func(v *api.BypassConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "resolver":
+		if v := findObject(cfg.Resolvers, name, func(v *api.ResolverConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "hosts":
+		if v := findObject(cfg.Hosts, name, func(v *api.HostsConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "limiter":
+		if v := findObject(cfg.Limiters, name, func(v *api.LimiterConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "observer":
+		if v := findObject(cfg.Observers, name, func(v *api.ObserverConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	case "recorder":
+		if v := findObject(cfg.Recorders, name, func(v *api.RecorderConfig) string { return v.Name }); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+type replayKey struct{}
+
+// stage queues the change instead of sending it if the current server is unreachable,
+// it reports whether the change was queued.
+func stage(ctx context.Context, kind string, op queue.Op, name string, value any) (bool, error) {
+	if ctx.Value(replayKey{}) != nil {
+		return false, nil
+	}
+
+	server := config.CurrentServer()
+	if server == nil || server.State() != config.ServerError {
+		return false, nil
+	}
+
+	c := queue.Change{
+		Kind: kind,
+		Op:   op,
+		Name: name,
+		Base: queue.Hash(lookup(kind, name)),
+	}
+	if value != nil {
+		v, err := json.Marshal(value)
+		if err != nil {
+			return true, err
+		}
+		c.Value = string(v)
+	}
+
+	return true, queue.Add(server.Name, c)
+}
+
+// fromChange returns the task applying the queued change.
+func fromChange(c queue.Change) (runner.Task, error) {
+	switch c.Kind {
+	case "service":
+		v := &api.ServiceConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteService(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateService(v), nil
+		}
+		return UpdateService(v), nil
+	case "chain":
+		v := &api.ChainConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteChain(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateChain(v), nil
+		}
+		return UpdateChain(v), nil
+	case "hop":
+		v := &api.HopConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteHop(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateHop(v), nil
+		}
+		return UpdateHop(v), nil
+	case "auther":
+		v := &api.AutherConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteAuther(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateAuther(v), nil
+		}
+		return UpdateAuther(v), nil
+	case "admission":
+		v := &api.AdmissionConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteAdmission(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateAdmission(v), nil
+		}
+		return UpdateAdmission(v), nil
+	case "bypass":
+		v := &api.BypassConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteBypass(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateBypass(v), nil
+		}
+		return UpdateBypass(v), nil
+	case "resolver":
+		v := &api.ResolverConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteResolver(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateResolver(v), nil
+		}
+		return UpdateResolver(v), nil
+	case "hosts":
+		v := &api.HostsConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteHostMapper(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateHostMapper(v), nil
+		}
+		return UpdateHostMapper(v), nil
+	case "limiter":
+		v := &api.LimiterConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteLimiter(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateLimiter(v), nil
+		}
+		return UpdateLimiter(v), nil
+	case "observer":
+		v := &api.ObserverConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteObserver(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateObserver(v), nil
+		}
+		return UpdateObserver(v), nil
+	case "recorder":
+		v := &api.RecorderConfig{}
+		if c.Op == queue.OpDelete {
+			return DeleteRecorder(c.Name), nil
+		}
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			return nil, err
+		}
+		if c.Op == queue.OpCreate {
+			return CreateRecorder(v), nil
+		}
+		return UpdateRecorder(v), nil
+	}
+	return nil, fmt.Errorf("unknown kind %s", c.Kind)
+}
+
+// conflict reports why the queued change no longer applies to the remote object.
+func conflict(c queue.Change) string {
+	current := lookup(c.Kind, c.Name)
+	if c.Op == queue.OpCreate {
+		if current != nil {
+			return fmt.Sprintf("%s %s already exists", c.Kind, c.Name)
+		}
+		return ""
+	}
+	if c.Base != "" && queue.Hash(current) != c.Base {
+		return fmt.Sprintf("%s %s has been changed on the server", c.Kind, c.Name)
+	}
+	return ""
+}
+
+var (
+	replayMutex sync.Mutex
+)
+
+type replayQueueTask struct {
+	server string
+}
+
+// ReplayQueue sends the changes queued for server in order.
+// The replay stops at the first change which fails or conflicts with the remote object,
+// the change is marked so that it is not replayed again until it is resolved.
+func ReplayQueue(server string) runner.Task {
+	return &replayQueueTask{
+		server: server,
+	}
+}
+
+func (t *replayQueueTask) ID() runner.TaskID {
+	return runner.TaskReplayQueue
+}
+
+func (t *replayQueueTask) Resource() string {
+	return t.server
+}
+
+func (t *replayQueueTask) Run(ctx context.Context) error {
+	// the queue is replayed once at a time.
+	if !replayMutex.TryLock() {
+		return nil
+	}
+	defer replayMutex.Unlock()
+
+	ctx = context.WithValue(ctx, replayKey{}, true)
+
+	for _, c := range queue.List(t.server) {
+		if c.Conflict != "" || c.Failed != "" {
+			return nil
+		}
+
+		if msg := conflict(c); msg != "" {
+			c.Conflict = msg
+			return errors.Join(errors.New(msg), queue.Update(t.server, c))
+		}
+
+		task, err := fromChange(c)
+		if err == nil {
+			err = task.Run(ctx)
+		}
+		if err != nil {
+			// the server is unreachable again, the replay is resumed by the next successful poll.
+			var uerr *url.Error
+			if errors.As(err, &uerr) || ctx.Err() != nil {
+				return err
+			}
+			// the change rejected is reported once, and not sent again until it is retried.
+			c.Failed = err.Error()
+			return errors.Join(err, queue.Update(t.server, c))
+		}
+
+		if err := queue.Remove(t.server, c.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rebase resolves the conflict of the queued change in favor of the change,
+// the remote object is overwritten when the queue is replayed.
+func Rebase(c queue.Change) queue.Change {
+	current := lookup(c.Kind, c.Name)
+	c.Base = queue.Hash(current)
+	c.Conflict = ""
+	if c.Op == queue.OpCreate && current != nil {
+		c.Op = queue.OpUpdate
+	}
+	return c
+}
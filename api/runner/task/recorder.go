@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createRecorderTask) ID() runner.TaskID {
 	return runner.TaskCreateRecorder
 }
 
+func (t *createRecorderTask) Resource() string {
+	if t.recorder == nil {
+		return ""
+	}
+	return t.recorder.Name
+}
+
+func (t *createRecorderTask) Summary() string {
+	return summary(t.recorder)
+}
+
 func (t *createRecorderTask) Run(ctx context.Context) (err error) {
 	if t.recorder == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "recorder", queue.OpCreate, t.recorder.Name, t.recorder); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create recorder %s: %v", t.recorder.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateRecorderTask) ID() runner.TaskID {
 	return runner.TaskUpdateRecorder
 }
 
+func (t *updateRecorderTask) Resource() string {
+	if t.recorder == nil {
+		return ""
+	}
+	return t.recorder.Name
+}
+
+func (t *updateRecorderTask) Summary() string {
+	return summary(t.recorder)
+}
+
 func (t *updateRecorderTask) Run(ctx context.Context) (err error) {
 	if t.recorder == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "recorder", queue.OpUpdate, t.recorder.Name, t.recorder); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update recorder %s: %v", t.recorder.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteRecorderTask) ID() runner.TaskID {
 	return runner.TaskDeleteRecorder
 }
 
+func (t *deleteRecorderTask) Resource() string {
+	return t.recorder
+}
+
+func (t *deleteRecorderTask) Summary() string {
+	return t.recorder
+}
+
 func (t *deleteRecorderTask) Run(ctx context.Context) (err error) {
 	if t.recorder == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "recorder", queue.OpDelete, t.recorder, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete recorder %s: %v", t.recorder, err))
 	}()
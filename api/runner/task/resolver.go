@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createResolverTask) ID() runner.TaskID {
 	return runner.TaskCreateResolver
 }
 
+func (t *createResolverTask) Resource() string {
+	if t.resolver == nil {
+		return ""
+	}
+	return t.resolver.Name
+}
+
+func (t *createResolverTask) Summary() string {
+	return summary(t.resolver)
+}
+
 func (t *createResolverTask) Run(ctx context.Context) (err error) {
 	if t.resolver == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "resolver", queue.OpCreate, t.resolver.Name, t.resolver); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create resolver %s: %v", t.resolver.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateResolverTask) ID() runner.TaskID {
 	return runner.TaskUpdateResolver
 }
 
+func (t *updateResolverTask) Resource() string {
+	if t.resolver == nil {
+		return ""
+	}
+	return t.resolver.Name
+}
+
+func (t *updateResolverTask) Summary() string {
+	return summary(t.resolver)
+}
+
 func (t *updateResolverTask) Run(ctx context.Context) (err error) {
 	if t.resolver == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "resolver", queue.OpUpdate, t.resolver.Name, t.resolver); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update resolver %s: %v", t.resolver.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteResolverTask) ID() runner.TaskID {
 	return runner.TaskDeleteResolver
 }
 
+func (t *deleteResolverTask) Resource() string {
+	return t.resolver
+}
+
+func (t *deleteResolverTask) Summary() string {
+	return t.resolver
+}
+
 func (t *deleteResolverTask) Run(ctx context.Context) (err error) {
 	if t.resolver == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "resolver", queue.OpDelete, t.resolver, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete resolver %s: %v", t.resolver, err))
 	}()
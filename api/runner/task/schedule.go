@@ -0,0 +1,104 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-gost/gostctl/api/queue"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/config"
+	"gopkg.in/yaml.v3"
+)
+
+type scheduleTask struct {
+	schedule config.Schedule
+}
+
+// Schedule runs the action of the schedule s on the current server,
+// each execution is recorded as an event of the server.
+func Schedule(s *config.Schedule) runner.Task {
+	return &scheduleTask{
+		schedule: *s,
+	}
+}
+
+func (t *scheduleTask) ID() runner.TaskID {
+	return runner.TaskSchedule + runner.TaskID(t.schedule.Name)
+}
+
+func (t *scheduleTask) Resource() string {
+	if t.schedule.Action == config.ScheduleSave {
+		return t.schedule.Target
+	}
+	return fmt.Sprintf("%s %s", t.schedule.Kind, t.schedule.Target)
+}
+
+func (t *scheduleTask) Summary() string {
+	return fmt.Sprintf("%s %s", t.schedule.Action, t.Resource())
+}
+
+func (t *scheduleTask) Run(ctx context.Context) error {
+	task, err := ScheduleAction(&t.schedule)
+	if err == nil {
+		err = task.Run(ctx)
+	}
+
+	if server := config.CurrentServer(); server != nil {
+		msg := fmt.Sprintf("schedule %s: %s done", t.schedule.Name, t.Summary())
+		if err != nil {
+			msg = fmt.Sprintf("schedule %s: %s failed: %v", t.schedule.Name, t.Summary(), err)
+		}
+		server.AddEvent(config.ServerEvent{
+			Time: time.Now(),
+			Msg:  msg,
+		})
+	}
+
+	return err
+}
+
+// ScheduleAction returns the task carrying out the action of the schedule s.
+func ScheduleAction(s *config.Schedule) (runner.Task, error) {
+	switch s.Action {
+	case config.ScheduleSave:
+		return SaveConfig(s.Target), nil
+	case config.ScheduleCreate, config.ScheduleUpdate, config.ScheduleDelete:
+	default:
+		return nil, fmt.Errorf("unknown action %s", s.Action)
+	}
+
+	c := queue.Change{
+		Kind: s.Kind,
+		Op:   queue.Op(s.Action),
+		Name: s.Target,
+	}
+	if s.Action != config.ScheduleDelete {
+		// the value is YAML, or JSON which is YAML too.
+		value, err := s.PlainValue()
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if err := yaml.Unmarshal([]byte(value), &v); err != nil {
+			return nil, err
+		}
+		// the object is always the target of the schedule.
+		if m, ok := v.(map[string]any); ok {
+			m["name"] = s.Target
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		c.Value = string(b)
+	}
+
+	return fromChange(c)
+}
+
+// Object returns the named object of kind in the current config, or nil if it does not exist.
+func Object(kind string, name string) any {
+	return lookup(kind, name)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 )
 
@@ -26,11 +27,26 @@ func (t *createServiceTask) ID() runner.TaskID {
 	return runner.TaskCreateService
 }
 
+func (t *createServiceTask) Resource() string {
+	if t.service == nil {
+		return ""
+	}
+	return t.service.Name
+}
+
+func (t *createServiceTask) Summary() string {
+	return summary(t.service)
+}
+
 func (t *createServiceTask) Run(ctx context.Context) (err error) {
 	if t.service == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "service", queue.OpCreate, t.service.Name, t.service); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("create service %s: %v", t.service.Name, err))
 	}()
@@ -60,11 +76,26 @@ func (t *updateServiceTask) ID() runner.TaskID {
 	return runner.TaskUpdateService
 }
 
+func (t *updateServiceTask) Resource() string {
+	if t.service == nil {
+		return ""
+	}
+	return t.service.Name
+}
+
+func (t *updateServiceTask) Summary() string {
+	return summary(t.service)
+}
+
 func (t *updateServiceTask) Run(ctx context.Context) (err error) {
 	if t.service == nil {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "service", queue.OpUpdate, t.service.Name, t.service); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("update service %s: %v", t.service.Name, err))
 	}()
@@ -94,11 +125,23 @@ func (t *deleteServiceTask) ID() runner.TaskID {
 	return runner.TaskDeleteService
 }
 
+func (t *deleteServiceTask) Resource() string {
+	return t.service
+}
+
+func (t *deleteServiceTask) Summary() string {
+	return t.service
+}
+
 func (t *deleteServiceTask) Run(ctx context.Context) (err error) {
 	if t.service == "" {
 		return nil
 	}
 
+	if staged, err := stage(ctx, "service", queue.OpDelete, t.service, nil); staged {
+		return err
+	}
+
 	defer func() {
 		slog.With("kind", "task", "task", t.ID()).DebugContext(ctx, fmt.Sprintf("delete service %s: %v", t.service, err))
 	}()
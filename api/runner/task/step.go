@@ -0,0 +1,363 @@
+package task
+
+import (
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/api/runner"
+)
+
+// The step functions build the steps of a runner.Plan.
+// The undo task of an update or delete step restores the object as found in the current config.
+
+func findObject[T any](objs []*T, name string, nameOf func(*T) string) *T {
+	for _, obj := range objs {
+		if obj != nil && nameOf(obj) == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func CreateServiceStep(service *api.ServiceConfig) runner.Step {
+	return runner.Step{
+		Task: CreateService(service),
+		Undo: DeleteService(service.Name),
+	}
+}
+
+func UpdateServiceStep(service *api.ServiceConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateService(service),
+	}
+	old := findObject(api.GetConfig().Services, service.Name, func(v *api.ServiceConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		// status is read-only and must not be sent back.
+		old.Status = nil
+		step.Undo = UpdateService(old)
+	}
+	return step
+}
+
+func DeleteServiceStep(service string) runner.Step {
+	step := runner.Step{
+		Task: DeleteService(service),
+	}
+	old := findObject(api.GetConfig().Services, service, func(v *api.ServiceConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		// status is read-only and must not be sent back.
+		old.Status = nil
+		step.Undo = CreateService(old)
+	}
+	return step
+}
+
+func CreateChainStep(chain *api.ChainConfig) runner.Step {
+	return runner.Step{
+		Task: CreateChain(chain),
+		Undo: DeleteChain(chain.Name),
+	}
+}
+
+func UpdateChainStep(chain *api.ChainConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateChain(chain),
+	}
+	old := findObject(api.GetConfig().Chains, chain.Name, func(v *api.ChainConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateChain(old)
+	}
+	return step
+}
+
+func DeleteChainStep(chain string) runner.Step {
+	step := runner.Step{
+		Task: DeleteChain(chain),
+	}
+	old := findObject(api.GetConfig().Chains, chain, func(v *api.ChainConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateChain(old)
+	}
+	return step
+}
+
+func CreateHopStep(hop *api.HopConfig) runner.Step {
+	return runner.Step{
+		Task: CreateHop(hop),
+		Undo: DeleteHop(hop.Name),
+	}
+}
+
+func UpdateHopStep(hop *api.HopConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateHop(hop),
+	}
+	old := findObject(api.GetConfig().Hops, hop.Name, func(v *api.HopConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateHop(old)
+	}
+	return step
+}
+
+func DeleteHopStep(hop string) runner.Step {
+	step := runner.Step{
+		Task: DeleteHop(hop),
+	}
+	old := findObject(api.GetConfig().Hops, hop, func(v *api.HopConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateHop(old)
+	}
+	return step
+}
+
+func CreateAutherStep(auther *api.AutherConfig) runner.Step {
+	return runner.Step{
+		Task: CreateAuther(auther),
+		Undo: DeleteAuther(auther.Name),
+	}
+}
+
+func UpdateAutherStep(auther *api.AutherConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateAuther(auther),
+	}
+	old := findObject(api.GetConfig().Authers, auther.Name, func(v *api.AutherConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateAuther(old)
+	}
+	return step
+}
+
+func DeleteAutherStep(auther string) runner.Step {
+	step := runner.Step{
+		Task: DeleteAuther(auther),
+	}
+	old := findObject(api.GetConfig().Authers, auther, func(v *api.AutherConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateAuther(old)
+	}
+	return step
+}
+
+func CreateAdmissionStep(admission *api.AdmissionConfig) runner.Step {
+	return runner.Step{
+		Task: CreateAdmission(admission),
+		Undo: DeleteAdmission(admission.Name),
+	}
+}
+
+func UpdateAdmissionStep(admission *api.AdmissionConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateAdmission(admission),
+	}
+	old := findObject(api.GetConfig().Admissions, admission.Name, func(v *api.AdmissionConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateAdmission(old)
+	}
+	return step
+}
+
+func DeleteAdmissionStep(admission string) runner.Step {
+	step := runner.Step{
+		Task: DeleteAdmission(admission),
+	}
+	old := findObject(api.GetConfig().Admissions, admission, func(v *api.AdmissionConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateAdmission(old)
+	}
+	return step
+}
+
+func CreateBypassStep(bypass *api.BypassConfig) runner.Step {
+	return runner.Step{
+		Task: CreateBypass(bypass),
+		Undo: DeleteBypass(bypass.Name),
+	}
+}
+
+func UpdateBypassStep(bypass *api.BypassConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateBypass(bypass),
+	}
+	old := findObject(api.GetConfig().Bypasses, bypass.Name, func(v *api.BypassConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateBypass(old)
+	}
+	return step
+}
+
+func DeleteBypassStep(bypass string) runner.Step {
+	step := runner.Step{
+		Task: DeleteBypass(bypass),
+	}
+	old := findObject(api.GetConfig().Bypasses, bypass, func(v *api.BypassConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateBypass(old)
+	}
+	return step
+}
+
+func CreateResolverStep(resolver *api.ResolverConfig) runner.Step {
+	return runner.Step{
+		Task: CreateResolver(resolver),
+		Undo: DeleteResolver(resolver.Name),
+	}
+}
+
+func UpdateResolverStep(resolver *api.ResolverConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateResolver(resolver),
+	}
+	old := findObject(api.GetConfig().Resolvers, resolver.Name, func(v *api.ResolverConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateResolver(old)
+	}
+	return step
+}
+
+func DeleteResolverStep(resolver string) runner.Step {
+	step := runner.Step{
+		Task: DeleteResolver(resolver),
+	}
+	old := findObject(api.GetConfig().Resolvers, resolver, func(v *api.ResolverConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateResolver(old)
+	}
+	return step
+}
+
+func CreateHostMapperStep(hostMapper *api.HostsConfig) runner.Step {
+	return runner.Step{
+		Task: CreateHostMapper(hostMapper),
+		Undo: DeleteHostMapper(hostMapper.Name),
+	}
+}
+
+func UpdateHostMapperStep(hostMapper *api.HostsConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateHostMapper(hostMapper),
+	}
+	old := findObject(api.GetConfig().Hosts, hostMapper.Name, func(v *api.HostsConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateHostMapper(old)
+	}
+	return step
+}
+
+func DeleteHostMapperStep(hostMapper string) runner.Step {
+	step := runner.Step{
+		Task: DeleteHostMapper(hostMapper),
+	}
+	old := findObject(api.GetConfig().Hosts, hostMapper, func(v *api.HostsConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateHostMapper(old)
+	}
+	return step
+}
+
+func CreateLimiterStep(limiter *api.LimiterConfig) runner.Step {
+	return runner.Step{
+		Task: CreateLimiter(limiter),
+		Undo: DeleteLimiter(limiter.Name),
+	}
+}
+
+func UpdateLimiterStep(limiter *api.LimiterConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateLimiter(limiter),
+	}
+	old := findObject(api.GetConfig().Limiters, limiter.Name, func(v *api.LimiterConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateLimiter(old)
+	}
+	return step
+}
+
+func DeleteLimiterStep(limiter string) runner.Step {
+	step := runner.Step{
+		Task: DeleteLimiter(limiter),
+	}
+	old := findObject(api.GetConfig().Limiters, limiter, func(v *api.LimiterConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateLimiter(old)
+	}
+	return step
+}
+
+func CreateObserverStep(observer *api.ObserverConfig) runner.Step {
+	return runner.Step{
+		Task: CreateObserver(observer),
+		Undo: DeleteObserver(observer.Name),
+	}
+}
+
+func UpdateObserverStep(observer *api.ObserverConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateObserver(observer),
+	}
+	old := findObject(api.GetConfig().Observers, observer.Name, func(v *api.ObserverConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateObserver(old)
+	}
+	return step
+}
+
+func DeleteObserverStep(observer string) runner.Step {
+	step := runner.Step{
+		Task: DeleteObserver(observer),
+	}
+	old := findObject(api.GetConfig().Observers, observer, func(v *api.ObserverConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateObserver(old)
+	}
+	return step
+}
+
+func CreateRecorderStep(recorder *api.RecorderConfig) runner.Step {
+	return runner.Step{
+		Task: CreateRecorder(recorder),
+		Undo: DeleteRecorder(recorder.Name),
+	}
+}
+
+func UpdateRecorderStep(recorder *api.RecorderConfig) runner.Step {
+	step := runner.Step{
+		Task: UpdateRecorder(recorder),
+	}
+	old := findObject(api.GetConfig().Recorders, recorder.Name, func(v *api.RecorderConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = UpdateRecorder(old)
+	}
+	return step
+}
+
+func DeleteRecorderStep(recorder string) runner.Step {
+	step := runner.Step{
+		Task: DeleteRecorder(recorder),
+	}
+	old := findObject(api.GetConfig().Recorders, recorder, func(v *api.RecorderConfig) string { return v.Name })
+	if old != nil {
+		old = old.Copy()
+		step.Undo = CreateRecorder(old)
+	}
+	return step
+}
@@ -0,0 +1,64 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxSummaryLen = 512
+	redacted      = "***"
+)
+
+// secretKeys are the keys of the credentials redacted from the summary, in lower case.
+var secretKeys = map[string]bool{
+	"password":   true,
+	"passphrase": true,
+	"token":      true,
+	"secret":     true,
+}
+
+// summary returns the JSON payload of v with the credentials redacted, truncated to fit in the task journal.
+func summary(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+
+	var m any
+	if err := json.Unmarshal(b, &m); err == nil {
+		if b, err = json.Marshal(redact(m)); err != nil {
+			return err.Error()
+		}
+	}
+
+	if len(b) > maxSummaryLen {
+		n := maxSummaryLen
+		// truncate on a rune boundary.
+		for n > 0 && !utf8.RuneStart(b[n]) {
+			n--
+		}
+		return string(b[:n]) + "..."
+	}
+	return string(b)
+}
+
+// redact replaces the values of the credentials in the decoded JSON value v.
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, vv := range v {
+			if s, ok := vv.(string); ok && s != "" && secretKeys[strings.ToLower(k)] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redact(vv)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return v
+}
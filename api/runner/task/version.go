@@ -0,0 +1,93 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/config"
+)
+
+// ConflictError is returned by a checked update if the remote object has changed since it was loaded.
+type ConflictError struct {
+	Kind string
+	Name string
+	// Remote is the current remote object, nil if it has been deleted.
+	Remote any
+	// Version is the version of Remote.
+	Version string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s has been changed on the server", e.Kind, e.Name)
+}
+
+// Version returns the version of the named object in the current config, or an empty string if it does not exist.
+func Version(kind string, name string) string {
+	return queue.Hash(lookup(kind, name))
+}
+
+type checkVersionTask struct {
+	kind    string
+	name    string
+	version string
+	task    runner.Task
+}
+
+// CheckVersion runs task only if the remote object is still at version,
+// otherwise it returns a *ConflictError. An empty version skips the check.
+func CheckVersion(kind string, name string, version string, task runner.Task) runner.Task {
+	return &checkVersionTask{
+		kind:    kind,
+		name:    name,
+		version: version,
+		task:    task,
+	}
+}
+
+// VersionChecked reports whether task is made by CheckVersion, it fails again if it is run again
+// as the version it checks is the one of the object when the task was made.
+func VersionChecked(task runner.Task) bool {
+	_, ok := task.(*checkVersionTask)
+	return ok
+}
+
+func (t *checkVersionTask) ID() runner.TaskID {
+	return t.task.ID()
+}
+
+func (t *checkVersionTask) Resource() string {
+	return t.name
+}
+
+func (t *checkVersionTask) Summary() string {
+	if v, ok := t.task.(runner.Summarizer); ok {
+		return v.Summary()
+	}
+	return ""
+}
+
+func (t *checkVersionTask) Run(ctx context.Context) error {
+	// the staged changes are checked when the queue is replayed.
+	if server := config.CurrentServer(); t.version == "" || server == nil || server.State() == config.ServerError {
+		return t.task.Run(ctx)
+	}
+
+	cfg, err := client.Default().GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+	remote := lookupIn(cfg, t.kind, t.name)
+	if version := queue.Hash(remote); version != t.version {
+		return &ConflictError{
+			Kind:    t.kind,
+			Name:    t.name,
+			Remote:  remote,
+			Version: version,
+		}
+	}
+
+	return t.task.Run(ctx)
+}
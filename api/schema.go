@@ -0,0 +1,151 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetadataKind is the kind of the component the metadata belongs to.
+type MetadataKind string
+
+const (
+	HandlerMetadata   MetadataKind = "handler"
+	ListenerMetadata  MetadataKind = "listener"
+	DialerMetadata    MetadataKind = "dialer"
+	ConnectorMetadata MetadataKind = "connector"
+)
+
+// ValueType is the type of a metadata value.
+type ValueType string
+
+const (
+	StringValue   ValueType = "string"
+	BoolValue     ValueType = "bool"
+	IntValue      ValueType = "int"
+	FloatValue    ValueType = "float"
+	DurationValue ValueType = "duration"
+)
+
+var ErrInvalidMetadataValue = errors.New("invalid metadata value")
+
+// MetadataField describes a known metadata key of a component type.
+type MetadataField struct {
+	Key     string
+	Type    ValueType
+	Default string
+	// Values are the allowed values, any value of Type is allowed if empty.
+	Values []string
+	Desc   string
+}
+
+// Parse converts the value in text to the type of the field.
+// A duration is kept in text, it is a Go duration or a number of seconds.
+func (f *MetadataField) Parse(s string) (any, error) {
+	if len(f.Values) > 0 && !slices.Contains(f.Values, s) {
+		return nil, fmt.Errorf("%w: %s is one of %s", ErrInvalidMetadataValue, f.Key, strings.Join(f.Values, ", "))
+	}
+
+	var v any
+	var err error
+	switch f.Type {
+	case BoolValue:
+		v, err = strconv.ParseBool(s)
+	case IntValue:
+		v, err = strconv.Atoi(s)
+	case FloatValue:
+		v, err = strconv.ParseFloat(s, 64)
+	case DurationValue:
+		v = s
+		if _, err = time.ParseDuration(s); err != nil {
+			_, err = strconv.Atoi(s)
+		}
+	default:
+		v = s
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is a %s", ErrInvalidMetadataValue, f.Key, f.Type)
+	}
+	return v, nil
+}
+
+var metadataSchemas = struct {
+	// fields are the known fields by the component kind and type,
+	// the fields of the empty type are shared by all the types of the kind.
+	fields map[MetadataKind]map[string][]MetadataField
+	mu     sync.RWMutex
+}{
+	fields: make(map[MetadataKind]map[string][]MetadataField),
+}
+
+// RegisterMetadataSchema registers the known metadata fields of the component type,
+// replacing the registered fields of the same keys.
+// The fields of the empty type are shared by all the types of the kind.
+func RegisterMetadataSchema(kind MetadataKind, typ string, fields ...MetadataField) {
+	metadataSchemas.mu.Lock()
+	defer metadataSchemas.mu.Unlock()
+
+	types := metadataSchemas.fields[kind]
+	if types == nil {
+		types = make(map[string][]MetadataField)
+		metadataSchemas.fields[kind] = types
+	}
+
+	list := types[typ]
+	for _, field := range fields {
+		if i := slices.IndexFunc(list, func(f MetadataField) bool { return strings.EqualFold(f.Key, field.Key) }); i >= 0 {
+			list[i] = field
+		} else {
+			list = append(list, field)
+		}
+	}
+	types[typ] = list
+}
+
+// MetadataFields returns the known metadata fields of the component type, sorted by key.
+func MetadataFields(kind MetadataKind, typ string) []MetadataField {
+	metadataSchemas.mu.RLock()
+	defer metadataSchemas.mu.RUnlock()
+
+	types := metadataSchemas.fields[kind]
+	fields := slices.Clone(types[typ])
+	if typ != "" {
+		for _, field := range types[""] {
+			if !slices.ContainsFunc(fields, func(f MetadataField) bool { return strings.EqualFold(f.Key, field.Key) }) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	slices.SortFunc(fields, func(a, b MetadataField) int {
+		return strings.Compare(strings.ToLower(a.Key), strings.ToLower(b.Key))
+	})
+	return fields
+}
+
+// LookupMetadataField returns the known metadata field of the key, the key is case-insensitive.
+func LookupMetadataField(kind MetadataKind, typ string, key string) (MetadataField, bool) {
+	for _, field := range MetadataFields(kind, typ) {
+		if strings.EqualFold(field.Key, key) {
+			return field, true
+		}
+	}
+	return MetadataField{}, false
+}
+
+// MetadataValue returns the metadata value in text converted to the type of the known field,
+// the value is kept in text if the key is unknown or the value is invalid.
+func MetadataValue(kind MetadataKind, typ string, key string, s string) any {
+	field, ok := LookupMetadataField(kind, typ, key)
+	if !ok {
+		return s
+	}
+	v, err := field.Parse(s)
+	if err != nil {
+		return s
+	}
+	return v
+}
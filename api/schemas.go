@@ -0,0 +1,320 @@
+package api
+
+import "slices"
+
+// The known metadata of the builtin handlers, listeners, dialers and connectors of GOST.
+
+var (
+	muxMetadata = []MetadataField{
+		{Key: "mux.version", Type: IntValue, Default: "1", Values: []string{"1", "2"}, Desc: "version of the multiplexing protocol"},
+		{Key: "mux.keepaliveDisabled", Type: BoolValue, Default: "false", Desc: "disable the keepalive of the multiplexed session"},
+		{Key: "mux.keepaliveInterval", Type: DurationValue, Default: "10s", Desc: "interval of the keepalive"},
+		{Key: "mux.keepaliveTimeout", Type: DurationValue, Default: "30s", Desc: "timeout of the keepalive"},
+		{Key: "mux.maxFrameSize", Type: IntValue, Default: "32768", Desc: "maximum size of a frame in bytes"},
+		{Key: "mux.maxReceiveBuffer", Type: IntValue, Default: "4194304", Desc: "maximum size of the receive buffer of the session in bytes"},
+		{Key: "mux.maxStreamBuffer", Type: IntValue, Default: "65536", Desc: "maximum size of the receive buffer of a stream in bytes"},
+	}
+
+	udpMetadata = []MetadataField{
+		{Key: "ttl", Type: DurationValue, Default: "5s", Desc: "idle timeout of the UDP connection"},
+		{Key: "readBufferSize", Type: IntValue, Default: "4096", Desc: "size of the read buffer in bytes"},
+		{Key: "readQueueSize", Type: IntValue, Default: "128", Desc: "size of the queue of the received packets"},
+	}
+
+	quicMetadata = []MetadataField{
+		{Key: "keepAlive", Type: BoolValue, Default: "false", Desc: "enable the keepalive"},
+		{Key: "keepAlivePeriod", Type: DurationValue, Default: "10s", Desc: "period of the keepalive"},
+		{Key: "handshakeTimeout", Type: DurationValue, Default: "5s", Desc: "timeout of the handshake"},
+		{Key: "maxIdleTimeout", Type: DurationValue, Default: "30s", Desc: "maximum idle time of the connection"},
+		{Key: "maxStreams", Type: IntValue, Default: "100", Desc: "maximum number of the concurrent streams"},
+	}
+
+	wsListenerMetadata = []MetadataField{
+		{Key: "path", Type: StringValue, Default: "/ws", Desc: "path of the WebSocket endpoint"},
+		{Key: "handshakeTimeout", Type: DurationValue, Desc: "timeout of the handshake"},
+		{Key: "readHeaderTimeout", Type: DurationValue, Desc: "timeout of reading the request header"},
+		{Key: "readBufferSize", Type: IntValue, Desc: "size of the read buffer in bytes"},
+		{Key: "writeBufferSize", Type: IntValue, Desc: "size of the write buffer in bytes"},
+		{Key: "enableCompression", Type: BoolValue, Default: "false", Desc: "enable the per message compression"},
+	}
+
+	wsDialerMetadata = []MetadataField{
+		{Key: "host", Type: StringValue, Desc: "host of the request, the node address by default"},
+		{Key: "path", Type: StringValue, Default: "/ws", Desc: "path of the WebSocket endpoint"},
+		{Key: "handshakeTimeout", Type: DurationValue, Desc: "timeout of the handshake"},
+		{Key: "readBufferSize", Type: IntValue, Desc: "size of the read buffer in bytes"},
+		{Key: "writeBufferSize", Type: IntValue, Desc: "size of the write buffer in bytes"},
+		{Key: "enableCompression", Type: BoolValue, Default: "false", Desc: "enable the per message compression"},
+	}
+
+	phtMetadata = []MetadataField{
+		{Key: "authorizePath", Type: StringValue, Default: "/authorize", Desc: "path of the authorization"},
+		{Key: "pushPath", Type: StringValue, Default: "/push", Desc: "path of pushing the data"},
+		{Key: "pullPath", Type: StringValue, Default: "/pull", Desc: "path of pulling the data"},
+	}
+
+	grpcMetadata = []MetadataField{
+		{Key: "path", Type: StringValue, Desc: "custom path of the gRPC service"},
+		{Key: "keepalive", Type: BoolValue, Default: "false", Desc: "enable the keepalive"},
+		{Key: "keepalive.time", Type: DurationValue, Default: "30s", Desc: "interval of the keepalive pings"},
+		{Key: "keepalive.timeout", Type: DurationValue, Default: "30s", Desc: "timeout of the keepalive pings"},
+		{Key: "keepalive.permitWithoutStream", Type: BoolValue, Default: "false", Desc: "send the keepalive pings without active streams"},
+	}
+
+	dtlsMetadata = []MetadataField{
+		{Key: "mtu", Type: IntValue, Default: "1350", Desc: "maximum transmission unit"},
+		{Key: "bufferSize", Type: IntValue, Default: "1200", Desc: "size of the buffer in bytes"},
+		{Key: "flightInterval", Type: DurationValue, Default: "1s", Desc: "interval of the handshake retransmission"},
+	}
+
+	sniffingMetadata = []MetadataField{
+		{Key: "sniffing", Type: BoolValue, Default: "false", Desc: "sniff the protocol of the traffic"},
+		{Key: "sniffing.timeout", Type: DurationValue, Desc: "timeout of the sniffing"},
+	}
+
+	probeResistanceMetadata = []MetadataField{
+		{Key: "probeResistance", Type: StringValue, Desc: "response to the probes, e.g. code:404, web:example.com, host:example.com:80 or file:/path/to/file"},
+		{Key: "knock", Type: StringValue, Desc: "host enabling the proxy with the probe resistance"},
+	}
+)
+
+func init() {
+	registerHandlerMetadata()
+	registerListenerMetadata()
+	registerDialerMetadata()
+	registerConnectorMetadata()
+}
+
+func registerHandlerMetadata() {
+	RegisterMetadataSchema(HandlerMetadata, "",
+		MetadataField{Key: "readTimeout", Type: DurationValue, Desc: "timeout of reading the request"},
+		MetadataField{Key: "hash", Type: StringValue, Values: []string{"host"}, Desc: "key of the hash selector of the chain group"},
+	)
+
+	RegisterMetadataSchema(HandlerMetadata, "http", slices.Concat(probeResistanceMetadata, []MetadataField{
+		{Key: "udp", Type: BoolValue, Default: "false", Desc: "enable the UDP over HTTP"},
+		{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+		{Key: "authBasicRealm", Type: StringValue, Desc: "realm of the basic authentication"},
+	})...)
+	RegisterMetadataSchema(HandlerMetadata, "http2", probeResistanceMetadata...)
+	RegisterMetadataSchema(HandlerMetadata, "http3", probeResistanceMetadata...)
+	RegisterMetadataSchema(HandlerMetadata, "socks5",
+		MetadataField{Key: "notls", Type: BoolValue, Default: "false", Desc: "disable the TLS negotiation"},
+		MetadataField{Key: "bind", Type: BoolValue, Default: "false", Desc: "enable the BIND command"},
+		MetadataField{Key: "udp", Type: BoolValue, Default: "false", Desc: "enable the UDP ASSOCIATE command"},
+		MetadataField{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+		MetadataField{Key: "compatibilityMode", Type: BoolValue, Default: "false", Desc: "compatible with the standard SOCKS5 protocol"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "auto",
+		MetadataField{Key: "notls", Type: BoolValue, Default: "false", Desc: "disable the TLS negotiation of SOCKS5"},
+		MetadataField{Key: "bind", Type: BoolValue, Default: "false", Desc: "enable the BIND command of SOCKS5"},
+		MetadataField{Key: "udp", Type: BoolValue, Default: "false", Desc: "enable the UDP ASSOCIATE command of SOCKS5"},
+		MetadataField{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "relay",
+		MetadataField{Key: "bind", Type: BoolValue, Default: "false", Desc: "enable the BIND command"},
+		MetadataField{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+		MetadataField{Key: "noDelay", Type: BoolValue, Default: "false", Desc: "send the response header along with the data"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "ss",
+		MetadataField{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+	)
+	for _, typ := range []string{"tcp", "udp", "rtcp", "rudp", "red", "redu"} {
+		RegisterMetadataSchema(HandlerMetadata, typ, sniffingMetadata...)
+	}
+	RegisterMetadataSchema(HandlerMetadata, "red",
+		MetadataField{Key: "tproxy", Type: BoolValue, Default: "false", Desc: "use the transparent proxy mode of TPROXY"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "dns",
+		MetadataField{Key: "dns", Type: StringValue, Desc: "upstream name servers, separated by comma"},
+		MetadataField{Key: "ttl", Type: DurationValue, Desc: "TTL of the cached records, the TTL of the records by default"},
+		MetadataField{Key: "timeout", Type: DurationValue, Default: "5s", Desc: "timeout of the upstream query"},
+		MetadataField{Key: "prefer", Type: StringValue, Values: []string{"ipv4", "ipv6"}, Desc: "preferred IP version"},
+		MetadataField{Key: "clientIP", Type: StringValue, Desc: "client IP of the EDNS0 client subnet"},
+		MetadataField{Key: "bufferSize", Type: IntValue, Default: "1024", Desc: "size of the buffer in bytes"},
+		MetadataField{Key: "async", Type: BoolValue, Default: "false", Desc: "refresh the cache asynchronously"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "tun",
+		MetadataField{Key: "bufferSize", Type: IntValue, Default: "4096", Desc: "size of the buffer in bytes"},
+		MetadataField{Key: "keepAlive", Type: BoolValue, Default: "false", Desc: "enable the keepalive of the tunnel"},
+		MetadataField{Key: "ttl", Type: DurationValue, Default: "10s", Desc: "interval of the keepalive"},
+		MetadataField{Key: "passphrase", Type: StringValue, Desc: "passphrase of the authentication between the client and the server"},
+		MetadataField{Key: "p2p", Type: BoolValue, Default: "false", Desc: "forward the traffic between the clients"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "tunnel",
+		MetadataField{Key: "entrypoint", Type: StringValue, Desc: "address of the public entrypoint"},
+		MetadataField{Key: "ingress", Type: StringValue, Desc: "name of the ingress"},
+		MetadataField{Key: "sd", Type: StringValue, Desc: "name of the service discovery"},
+		MetadataField{Key: "tunnel.direct", Type: BoolValue, Default: "false", Desc: "allow the direct connection to the tunnel"},
+	)
+	RegisterMetadataSchema(HandlerMetadata, "file",
+		MetadataField{Key: "file.dir", Type: StringValue, Desc: "root directory of the file server"},
+	)
+}
+
+func registerListenerMetadata() {
+	RegisterMetadataSchema(ListenerMetadata, "",
+		MetadataField{Key: "backlog", Type: IntValue, Default: "128", Desc: "maximum number of the pending connections"},
+		MetadataField{Key: "proxyProtocol", Type: IntValue, Values: []string{"0", "1", "2"}, Desc: "version of the PROXY protocol accepted"},
+	)
+
+	RegisterMetadataSchema(ListenerMetadata, "tcp",
+		MetadataField{Key: "mptcp", Type: BoolValue, Default: "false", Desc: "enable the Multipath TCP"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "tls",
+		MetadataField{Key: "mptcp", Type: BoolValue, Default: "false", Desc: "enable the Multipath TCP"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "udp", slices.Concat(udpMetadata, []MetadataField{
+		{Key: "keepAlive", Type: BoolValue, Default: "false", Desc: "keep the connection after the response"},
+	})...)
+	for _, typ := range []string{"rudp", "redu", "ftcp"} {
+		RegisterMetadataSchema(ListenerMetadata, typ, udpMetadata...)
+	}
+	RegisterMetadataSchema(ListenerMetadata, "red",
+		MetadataField{Key: "tproxy", Type: BoolValue, Default: "false", Desc: "use the transparent proxy mode of TPROXY"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "redu",
+		MetadataField{Key: "tproxy", Type: BoolValue, Default: "false", Desc: "use the transparent proxy mode of TPROXY"},
+	)
+
+	RegisterMetadataSchema(ListenerMetadata, "ws", wsListenerMetadata...)
+	RegisterMetadataSchema(ListenerMetadata, "wss", wsListenerMetadata...)
+	RegisterMetadataSchema(ListenerMetadata, "mws", slices.Concat(wsListenerMetadata, muxMetadata)...)
+	RegisterMetadataSchema(ListenerMetadata, "mwss", slices.Concat(wsListenerMetadata, muxMetadata)...)
+	RegisterMetadataSchema(ListenerMetadata, "mtls", muxMetadata...)
+	RegisterMetadataSchema(ListenerMetadata, "mtcp", muxMetadata...)
+
+	RegisterMetadataSchema(ListenerMetadata, "h2",
+		MetadataField{Key: "path", Type: StringValue, Desc: "path of the HTTP/2 endpoint"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "h2c",
+		MetadataField{Key: "path", Type: StringValue, Desc: "path of the HTTP/2 endpoint"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "grpc", slices.Concat(grpcMetadata, []MetadataField{
+		{Key: "grpcInsecure", Type: BoolValue, Default: "false", Desc: "serve gRPC without TLS"},
+	})...)
+	RegisterMetadataSchema(ListenerMetadata, "quic", quicMetadata...)
+	RegisterMetadataSchema(ListenerMetadata, "http3", quicMetadata...)
+	RegisterMetadataSchema(ListenerMetadata, "wt", slices.Concat(quicMetadata, []MetadataField{
+		{Key: "path", Type: StringValue, Default: "/wt", Desc: "path of the WebTransport endpoint"},
+	})...)
+	RegisterMetadataSchema(ListenerMetadata, "icmp",
+		MetadataField{Key: "keepAlive", Type: BoolValue, Default: "false", Desc: "enable the keepalive"},
+		MetadataField{Key: "handshakeTimeout", Type: DurationValue, Default: "5s", Desc: "timeout of the handshake"},
+		MetadataField{Key: "maxIdleTimeout", Type: DurationValue, Default: "30s", Desc: "maximum idle time of the connection"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "dtls", dtlsMetadata...)
+	RegisterMetadataSchema(ListenerMetadata, "pht", phtMetadata...)
+
+	RegisterMetadataSchema(ListenerMetadata, "dns",
+		MetadataField{Key: "mode", Type: StringValue, Default: "udp", Values: []string{"udp", "tcp", "tls", "https"}, Desc: "protocol of the DNS server"},
+		MetadataField{Key: "readBufferSize", Type: IntValue, Default: "1024", Desc: "size of the read buffer in bytes"},
+		MetadataField{Key: "readTimeout", Type: DurationValue, Default: "2s", Desc: "timeout of reading the request"},
+		MetadataField{Key: "writeTimeout", Type: DurationValue, Default: "2s", Desc: "timeout of writing the response"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "ssh",
+		MetadataField{Key: "authorizedKeys", Type: StringValue, Desc: "file of the authorized public keys"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "sshd",
+		MetadataField{Key: "authorizedKeys", Type: StringValue, Desc: "file of the authorized public keys"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "tun",
+		MetadataField{Key: "name", Type: StringValue, Desc: "name of the TUN device"},
+		MetadataField{Key: "net", Type: StringValue, Desc: "IP addresses of the device in CIDR, separated by comma"},
+		MetadataField{Key: "peer", Type: StringValue, Desc: "peer address of the point to point device"},
+		MetadataField{Key: "mtu", Type: IntValue, Default: "1350", Desc: "maximum transmission unit"},
+		MetadataField{Key: "route", Type: StringValue, Desc: "routes through the device in CIDR, separated by comma"},
+		MetadataField{Key: "gw", Type: StringValue, Desc: "default gateway of the routes"},
+	)
+	RegisterMetadataSchema(ListenerMetadata, "tap",
+		MetadataField{Key: "name", Type: StringValue, Desc: "name of the TAP device"},
+		MetadataField{Key: "net", Type: StringValue, Desc: "IP address of the device in CIDR"},
+		MetadataField{Key: "mtu", Type: IntValue, Default: "1350", Desc: "maximum transmission unit"},
+		MetadataField{Key: "route", Type: StringValue, Desc: "routes through the device in CIDR, separated by comma"},
+		MetadataField{Key: "gw", Type: StringValue, Desc: "default gateway of the routes"},
+	)
+}
+
+func registerDialerMetadata() {
+	RegisterMetadataSchema(DialerMetadata, "ws", wsDialerMetadata...)
+	RegisterMetadataSchema(DialerMetadata, "wss", wsDialerMetadata...)
+	RegisterMetadataSchema(DialerMetadata, "mws", slices.Concat(wsDialerMetadata, muxMetadata)...)
+	RegisterMetadataSchema(DialerMetadata, "mwss", slices.Concat(wsDialerMetadata, muxMetadata)...)
+	RegisterMetadataSchema(DialerMetadata, "mtls", muxMetadata...)
+	RegisterMetadataSchema(DialerMetadata, "mtcp", muxMetadata...)
+
+	for _, typ := range []string{"http2", "h2", "h2c"} {
+		RegisterMetadataSchema(DialerMetadata, typ,
+			MetadataField{Key: "host", Type: StringValue, Desc: "host of the request, the node address by default"},
+			MetadataField{Key: "path", Type: StringValue, Desc: "path of the HTTP/2 endpoint"},
+		)
+	}
+	RegisterMetadataSchema(DialerMetadata, "grpc", slices.Concat(grpcMetadata, []MetadataField{
+		{Key: "host", Type: StringValue, Desc: "authority of the request, the node address by default"},
+		{Key: "grpcInsecure", Type: BoolValue, Default: "false", Desc: "connect to gRPC without TLS"},
+	})...)
+	RegisterMetadataSchema(DialerMetadata, "quic", quicMetadata...)
+	RegisterMetadataSchema(DialerMetadata, "http3", slices.Concat(quicMetadata, []MetadataField{
+		{Key: "host", Type: StringValue, Desc: "host of the request, the node address by default"},
+	})...)
+	RegisterMetadataSchema(DialerMetadata, "wt", slices.Concat(quicMetadata, []MetadataField{
+		{Key: "host", Type: StringValue, Desc: "host of the request, the node address by default"},
+		{Key: "path", Type: StringValue, Default: "/wt", Desc: "path of the WebTransport endpoint"},
+	})...)
+	RegisterMetadataSchema(DialerMetadata, "icmp",
+		MetadataField{Key: "keepAlive", Type: BoolValue, Default: "false", Desc: "enable the keepalive"},
+		MetadataField{Key: "handshakeTimeout", Type: DurationValue, Default: "5s", Desc: "timeout of the handshake"},
+		MetadataField{Key: "maxIdleTimeout", Type: DurationValue, Default: "30s", Desc: "maximum idle time of the connection"},
+	)
+	RegisterMetadataSchema(DialerMetadata, "dtls", dtlsMetadata...)
+	RegisterMetadataSchema(DialerMetadata, "pht", slices.Concat(phtMetadata, []MetadataField{
+		{Key: "host", Type: StringValue, Desc: "host of the request, the node address by default"},
+	})...)
+	RegisterMetadataSchema(DialerMetadata, "ohttp",
+		MetadataField{Key: "host", Type: StringValue, Desc: "host of the obfuscated request"},
+	)
+	RegisterMetadataSchema(DialerMetadata, "otls",
+		MetadataField{Key: "host", Type: StringValue, Desc: "server name of the obfuscated handshake"},
+	)
+	for _, typ := range []string{"ssh", "sshd"} {
+		RegisterMetadataSchema(DialerMetadata, typ,
+			MetadataField{Key: "privateKeyFile", Type: StringValue, Desc: "file of the private key of the authentication"},
+			MetadataField{Key: "passphrase", Type: StringValue, Desc: "passphrase of the private key"},
+		)
+	}
+}
+
+func registerConnectorMetadata() {
+	RegisterMetadataSchema(ConnectorMetadata, "",
+		MetadataField{Key: "connectTimeout", Type: DurationValue, Desc: "timeout of the connect request"},
+	)
+
+	RegisterMetadataSchema(ConnectorMetadata, "http",
+		MetadataField{Key: "userAgent", Type: StringValue, Desc: "User-Agent header of the request"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "http2",
+		MetadataField{Key: "userAgent", Type: StringValue, Desc: "User-Agent header of the request"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "socks4",
+		MetadataField{Key: "disable4a", Type: BoolValue, Default: "false", Desc: "disable the SOCKS4A protocol"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "socks5",
+		MetadataField{Key: "notls", Type: BoolValue, Default: "false", Desc: "disable the TLS negotiation"},
+		MetadataField{Key: "relay", Type: StringValue, Values: []string{"udp"}, Desc: "relay UDP over the TCP connection"},
+		MetadataField{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "relay",
+		MetadataField{Key: "noDelay", Type: BoolValue, Default: "false", Desc: "send the request header along with the data"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "ss",
+		MetadataField{Key: "udpBufferSize", Type: IntValue, Default: "4096", Desc: "size of the UDP buffer in bytes"},
+		MetadataField{Key: "noDelay", Type: BoolValue, Default: "false", Desc: "send the request header along with the data"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "sni",
+		MetadataField{Key: "host", Type: StringValue, Desc: "server name replacing the one of the TLS handshake or HTTP request"},
+	)
+	RegisterMetadataSchema(ConnectorMetadata, "tunnel",
+		MetadataField{Key: "tunnel.id", Type: StringValue, Desc: "ID of the tunnel"},
+		MetadataField{Key: "tunnel.weight", Type: IntValue, Desc: "weight of the connection in the tunnel"},
+	)
+}
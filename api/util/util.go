@@ -2,41 +2,129 @@ package util
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-gost/gostctl/api"
 	"github.com/go-gost/gostctl/api/client"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/runner"
 	"github.com/go-gost/gostctl/api/runner/task"
 	"github.com/go-gost/gostctl/config"
 )
 
-func RestartGetConfigTask() {
-	api.SetConfig(&api.Config{})
+type WindowState int32
 
-	server := config.CurrentServer()
-	if server == nil {
+const (
+	// WindowFocused is the state of the window the user is working in.
+	WindowFocused WindowState = iota
+	// WindowUnfocused is the state of the window visible but not focused.
+	WindowUnfocused
+	// WindowHidden is the state of the window minimized or sent to the background.
+	WindowHidden
+)
+
+const (
+	defaultInterval = 3 * time.Second
+	// unfocusedFactor slows down the polling while the window is not focused.
+	unfocusedFactor = 4
+)
+
+var (
+	windowState atomic.Int32
+	// configServer identifies the server the config was got from,
+	// the last config is kept while the server is unreachable and reset only when another server is selected.
+	configServer atomic.Value
+)
+
+// SetWindowState adapts the polling of the config to the state of the window.
+// The polling slows down while the window is not focused, pauses while it is hidden
+// unless the server has a background interval, and resumes immediately on focus.
+func SetWindowState(state WindowState) {
+	if WindowState(windowState.Swap(int32(state))) == state {
 		return
 	}
 
-	var userinfo *url.Userinfo
-	if server.Username != "" {
-		userinfo = url.UserPassword(server.Username, server.Password)
+	// the polling stays stopped while the secrets are locked.
+	if server := config.CurrentServer(); server != nil && !config.Locked() {
+		restartPolling(server, state)
 	}
-	client.SetDefault(client.NewClient(server.URL,
-		client.WithTimeout(server.Timeout),
-		client.WithUserinfo(userinfo),
-	))
+}
+
+// pollInterval returns the interval of polling the config of server in the window state,
+// zero if the polling is paused.
+func pollInterval(server *config.Server, state WindowState) time.Duration {
 	interval := server.Interval
 	if interval <= 0 {
-		interval = 3 * time.Second
+		interval = defaultInterval
 	}
+
+	switch state {
+	case WindowUnfocused:
+		return interval * unfocusedFactor
+	case WindowHidden:
+		return server.BackgroundInterval
+	default:
+		return interval
+	}
+}
+
+func restartPolling(server *config.Server, state WindowState) {
+	interval := pollInterval(server, state)
+	if interval <= 0 {
+		runner.Cancel(runner.TaskGetConfig)
+		return
+	}
+
+	// the server in error state is polled less and less often until it recovers.
 	runner.Exec(context.Background(), task.GetConfig(),
 		runner.WithAync(true),
 		runner.WithInterval(interval),
+		runner.WithBackoff(interval, max(interval, time.Minute)),
 		runner.WithCancel(true),
 	)
+}
+
+func RestartGetConfigTask() {
+	server := config.CurrentServer()
+
+	key := ""
+	if server != nil {
+		key = server.Name + "\n" + server.URL
+	}
+	if old, _ := configServer.Swap(key).(string); old != key {
+		api.SetConfig(&api.Config{})
+	}
+
+	if server == nil {
+		return
+	}
+	runner.SetServer(server.Name)
+
+	var userinfo *url.Userinfo
+	if server.Username != "" {
+		password, err := server.Secret()
+		if err != nil {
+			// the server is polled again once the secrets are unlocked.
+			runner.Cancel(runner.TaskGetConfig)
+			server.SetState(config.ServerError)
+			server.AddEvent(config.ServerEvent{
+				Time: time.Now(),
+				Msg:  err.Error(),
+			})
+			return
+		}
+		userinfo = url.UserPassword(server.Username, password)
+	}
+	client.SetDefault(client.NewClient(server.URL,
+		client.WithTimeout(server.Timeout),
+		client.WithUserinfo(userinfo),
+	))
+	restartPolling(server, WindowState(windowState.Load()))
 
 	if server.AutoSave != "" {
 		runner.Exec(context.Background(),
@@ -45,4 +133,64 @@ func RestartGetConfigTask() {
 			runner.WithCancel(true),
 		)
 	}
+
+	RestartSchedules()
+}
+
+var (
+	// schedules are the IDs of the running scheduled tasks.
+	schedules   []runner.TaskID
+	schedulesMu sync.Mutex
+)
+
+// RestartSchedules cancels the running scheduled tasks and starts the enabled schedules of the current server.
+func RestartSchedules() {
+	schedulesMu.Lock()
+	defer schedulesMu.Unlock()
+
+	for _, id := range schedules {
+		runner.Cancel(id)
+	}
+	schedules = nil
+
+	// the schedules change the read-only server, they are run only on the writable one.
+	server := config.CurrentServer()
+	if server == nil || server.IsReadonly() {
+		return
+	}
+
+	for _, s := range server.Schedules {
+		if s == nil || s.Disabled {
+			continue
+		}
+
+		cron, err := runner.ParseCron(s.Cron)
+		if err != nil {
+			slog.With("kind", "schedule").Error(fmt.Sprintf("schedule %s: %v", s.Name, err))
+			continue
+		}
+
+		t := task.Schedule(s)
+		if err := runner.Exec(context.Background(), t,
+			runner.WithAync(true),
+			runner.WithSchedule(cron),
+			runner.WithCancel(true),
+		); err != nil {
+			continue
+		}
+		schedules = append(schedules, t.ID())
+	}
+}
+
+// ReplayQueue sends the changes staged while the current server was unreachable.
+func ReplayQueue() {
+	server := config.CurrentServer()
+	if server == nil || len(queue.List(server.Name)) == 0 {
+		return
+	}
+
+	runner.Exec(context.Background(),
+		task.ReplayQueue(server.Name),
+		runner.WithAync(true),
+	)
 }
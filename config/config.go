@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -17,20 +18,25 @@ import (
 )
 
 const (
-	configFile = "gost.yml"
-	logFile    = "gost.log"
+	logFile = "gost.log"
 )
 
 var (
-	configDir string
+	configDir  string
+	configFile = "gost.yml"
 )
 
 func init() {
 	config.Store(&Config{})
 }
 
-func Init() {
-	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{AddSource: true})))
+func Init(opts ...Option) {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	slog.SetDefault(slog.New(newBufferHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{AddSource: true, Level: logLevel}))))
 
 	dir, err := app.DataDir()
 	if err != nil {
@@ -40,11 +46,16 @@ func Init() {
 		dir, _ = os.Getwd()
 	}
 	configDir = filepath.Join(dir, "gost")
+	setFile(&options)
 	os.MkdirAll(configDir, 0755)
 
 	slog.Info(fmt.Sprintf("appDir: %s", configDir))
+	slog.Info(fmt.Sprintf("config: %s", configFile))
 
 	cfg := Get()
+	// broken is set if the config file can not be parsed and is not recovered,
+	// the empty config is not written automatically then.
+	var broken bool
 	if err := cfg.load(); err != nil {
 		slog.Error(fmt.Sprintf("load config: %v", err))
 		if _, ok := err.(*os.PathError); ok {
@@ -58,18 +69,54 @@ func Init() {
 				})
 			}
 			cfg.Write()
+		} else if v, err := recoverConfig(); err != nil {
+			slog.Error(fmt.Sprintf("recover config: %v", err))
+			setBrokenAside()
+			broken = true
+		} else {
+			cfg = v
 		}
 	}
+
+	// the plain credentials of an old config file are sealed transparently.
+	created := initSecret(cfg)
 	Set(cfg)
+	switch {
+	case broken:
+	case created || cfg.migrated:
+		cfg.Write()
+	default:
+		migrateSecrets()
+	}
 
-	initLog()
+	readonly.Store(options.Readonly)
+	if options.Server != "" {
+		cfg = Get()
+		selectServer(cfg, options.Server)
+		Set(cfg)
+	}
+
+	initLog(options.LogLevel)
+
+	go watch()
+}
+
+// Dir returns the directory of the app data, such as the config file.
+func Dir() string {
+	return configDir
 }
 
-func initLog() {
+// initLog sets up the log by the config, level overrides the level of the config if it is not empty.
+// The recent records are always kept in memory for the log page.
+func initLog(level string) {
 	cfg := Get().Log
 	if cfg == nil {
-		return
+		cfg = &Log{}
+	}
+	if level == "" {
+		level = cfg.Level
 	}
+	logLevel.Set(parseLogLevel(level))
 
 	/*
 		logDir := filepath.Join(configDir, "logs")
@@ -100,34 +147,21 @@ func initLog() {
 			f, err := os.OpenFile(cfg.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 			if err != nil {
 				slog.Warn(fmt.Sprintf("open log file %s: %v", cfg.Output, err))
+				out = os.Stdout
 			} else {
 				out = f
 			}
 		}
 	}
 
-	level := &slog.LevelVar{}
-	switch cfg.Level {
-	case "debug":
-		level.Set(slog.LevelDebug)
-	case "warn":
-		level.Set(slog.LevelWarn)
-	case "error":
-		level.Set(slog.LevelError)
-	case "info":
-		fallthrough
-	default:
-		level.Set(slog.LevelInfo)
-	}
-
 	var handler slog.Handler
 	if cfg.Format == "text" {
-		handler = slog.NewTextHandler(out, &slog.HandlerOptions{AddSource: true, Level: level})
+		handler = slog.NewTextHandler(out, &slog.HandlerOptions{AddSource: true, Level: logLevel})
 	} else {
-		handler = slog.NewJSONHandler(out, &slog.HandlerOptions{AddSource: true, Level: level})
+		handler = slog.NewJSONHandler(out, &slog.HandlerOptions{AddSource: true, Level: logLevel})
 	}
 
-	slog.SetDefault(slog.New(handler))
+	slog.SetDefault(slog.New(newBufferHandler(handler)))
 }
 
 var (
@@ -142,16 +176,7 @@ func Get() *Config {
 }
 
 func CurrentServer() *Server {
-	cfg := Get()
-	if len(cfg.Servers) == 0 {
-		return nil
-	}
-
-	if cfg.CurrentServer >= 0 && cfg.CurrentServer < len(cfg.Servers) {
-		return cfg.Servers[cfg.CurrentServer]
-	}
-
-	return cfg.Servers[0]
+	return Get().currentServer()
 }
 
 func Set(c *Config) {
@@ -179,17 +204,28 @@ type ServerEvent struct {
 }
 
 type Server struct {
-	Name     string
+	Name string
+	// Group is the group of the server in the server list.
+	Group string `yaml:",omitempty"`
+	// Tags are free-form labels of the server, e.g. the region, to search and select the servers by.
+	Tags     []string      `yaml:",omitempty"`
 	URL      string        `yaml:"url"`
 	Username string        `yaml:",omitempty"`
 	Password string        `yaml:",omitempty"`
 	Interval time.Duration `yaml:",omitempty"`
-	Timeout  time.Duration `yaml:",omitempty"`
-	AutoSave string        `yaml:",omitempty"`
-	Readonly bool          `yaml:",omitempty"`
-	state    ServerState
-	events   []ServerEvent
-	mu       sync.RWMutex
+	// BackgroundInterval is the polling interval while the window is hidden,
+	// the polling is paused if it is zero.
+	BackgroundInterval time.Duration `yaml:",omitempty"`
+	Timeout            time.Duration `yaml:",omitempty"`
+	AutoSave           string        `yaml:",omitempty"`
+	Readonly           bool          `yaml:",omitempty"`
+	// Schedules are the actions run on the server at set times.
+	Schedules []*Schedule `yaml:",omitempty"`
+	// Extra holds the settings of the server unknown to the app.
+	Extra  map[string]any `yaml:",inline"`
+	state  ServerState
+	events []ServerEvent
+	mu     sync.RWMutex
 }
 
 func (s *Server) State() ServerState {
@@ -221,6 +257,30 @@ func (s *Server) AddEvent(event ServerEvent) {
 	}
 }
 
+type ScheduleAction string
+
+const (
+	ScheduleCreate ScheduleAction = "create"
+	ScheduleUpdate ScheduleAction = "update"
+	ScheduleDelete ScheduleAction = "delete"
+	ScheduleSave   ScheduleAction = "save"
+)
+
+// Schedule is an action run on the server at the times of a cron expression.
+type Schedule struct {
+	Name string
+	// Cron is a cron expression of five fields: minute, hour, day of month, month and day of week.
+	Cron   string
+	Action ScheduleAction
+	// Kind is the resource type of the target object, e.g. service or chain, unused by the save action.
+	Kind string `yaml:",omitempty"`
+	// Target is the name of the object, or the file path of the save action.
+	Target string `yaml:",omitempty"`
+	// Value is the object to create or update, in YAML or JSON.
+	Value    string `yaml:",omitempty"`
+	Disabled bool   `yaml:",omitempty"`
+}
+
 type Log struct {
 	Output   string
 	Level    string
@@ -252,35 +312,120 @@ type LogRotation struct {
 }
 
 type Config struct {
+	// Version is the version of the config layout, see CurrentVersion.
+	Version       int `yaml:"version"`
 	Servers       []*Server
 	CurrentServer int `yaml:"currentServer"`
 	Settings      Settings
 	Log           *Log
+	Secret        *Secret `yaml:",omitempty"`
+	// Extra holds the settings unknown to the app, e.g. written by a newer version.
+	Extra map[string]any `yaml:",inline"`
+
+	// migrated reports whether the config file is of an older version.
+	migrated bool
+}
+
+func (c *Config) currentServer() *Server {
+	if len(c.Servers) == 0 {
+		return nil
+	}
+
+	if c.CurrentServer >= 0 && c.CurrentServer < len(c.Servers) {
+		return c.Servers[c.CurrentServer]
+	}
+
+	return c.Servers[0]
 }
 
 func (c *Config) load() error {
-	f, err := os.Open(filepath.Join(configDir, configFile))
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(configDir, configFile))
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	return yaml.NewDecoder(f).Decode(c)
+	// the config is left unchanged if the file is broken.
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return err
+	}
+	remember(data)
+	*c = *cfg
+	return nil
+}
+
+// parseConfig decodes the content of the config file, which is migrated if it is of an older version.
+func parseConfig(data []byte) (*Config, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, errors.New("empty config file")
+	}
+
+	data, _, migrated, err := migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	cfg.migrated = migrated
+	return cfg, nil
 }
 
 func (c *Config) Write() error {
+	_, err := c.write()
+	return err
+}
+
+// write writes the config file, it reports whether the config is merged into the file changed by others,
+// the merged config is set as the config then.
+func (c *Config) write() (merged bool, err error) {
 	if c == nil {
 		c = &Config{}
 	}
 
+	// the version of a config written by a newer app is kept, along with the settings unknown to this one.
+	if c.Version < CurrentVersion {
+		c.Version = CurrentVersion
+	}
+
+	// the copies of the servers are sealed, the ones in use are shared and left unchanged.
+	out := *c
+	if c.Secret != nil {
+		servers, err := sealServers(c.Servers)
+		if err != nil {
+			return false, err
+		}
+		out.Servers = servers
+	}
+
 	var buf bytes.Buffer
 	enc := yaml.NewEncoder(&buf)
 	defer enc.Close()
 
 	enc.SetIndent(2)
-	if err := enc.Encode(c); err != nil {
-		return err
+	if err := enc.Encode(&out); err != nil {
+		return false, err
 	}
 
-	return os.WriteFile(filepath.Join(configDir, configFile), buf.Bytes(), 0644)
+	file.mu.Lock()
+	// the file changed by others is not overwritten, the config is merged into it instead.
+	if _, _, ok := changed(); ok {
+		file.mu.Unlock()
+		return conflict(c, buf.Bytes())
+	}
+	defer file.mu.Unlock()
+
+	if err := backup(); err != nil {
+		slog.Warn(fmt.Sprintf("backup config: %v", err))
+	}
+	if err := WriteFile(filepath.Join(configDir, configFile), buf.Bytes(), 0644); err != nil {
+		return false, err
+	}
+	remember(buf.Bytes())
+	return false, nil
 }
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	backupDir = "backup"
+	// maxBackups is the number of the backups of the config file to keep.
+	maxBackups    = 10
+	backupTimeFmt = "20060102-150405.000"
+)
+
+// WriteFile writes data to the named file atomically, the file is either fully written or left unchanged.
+// The data is written to a temporary file in the same directory, synced and renamed to the file.
+func WriteFile(name string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(name)
+	os.MkdirAll(dir, 0755)
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return err
+	}
+
+	// the rename is durable once the directory is synced, it is not supported on all systems.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// backup copies the config file to the backup directory before it is overwritten,
+// unless it is the same as the newest backup. The oldest backups beyond maxBackups are removed.
+func backup() error {
+	data, err := os.ReadFile(filepath.Join(configDir, configFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	backups := listBackups()
+	if len(backups) > 0 {
+		if b, err := os.ReadFile(backups[0]); err == nil && bytes.Equal(b, data) {
+			return nil
+		}
+	}
+
+	ext := filepath.Ext(configFile)
+	name := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(configFile, ext), time.Now().Format(backupTimeFmt), ext)
+	if err := WriteFile(filepath.Join(configDir, backupDir, name), data, 0600); err != nil {
+		return err
+	}
+
+	backups = listBackups()
+	for i := maxBackups; i < len(backups); i++ {
+		os.Remove(backups[i])
+	}
+	return nil
+}
+
+// listBackups returns the paths of the backups of the config file, the newest first.
+func listBackups() []string {
+	ext := filepath.Ext(configFile)
+	backups, _ := filepath.Glob(filepath.Join(configDir, backupDir, strings.TrimSuffix(configFile, ext)+"-*"+ext))
+	// the names sort by the time in them.
+	slices.Sort(backups)
+	slices.Reverse(backups)
+	return backups
+}
+
+// recoverConfig restores the config from the newest valid backup, after the config file failed to parse.
+// The broken file is kept beside the config file for inspection.
+func recoverConfig() (*Config, error) {
+	for _, name := range listBackups() {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+
+		// the backup is restored as it is, it is migrated like the config file when loaded.
+		cfg, err := parseConfig(data)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("backup %s: %v", name, err))
+			continue
+		}
+
+		file.mu.Lock()
+		path := filepath.Join(configDir, configFile)
+		moveBroken(path)
+		err = WriteFile(path, data, 0644)
+		if err == nil {
+			remember(data)
+		}
+		file.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Warn(fmt.Sprintf("config recovered from backup %s", name))
+		return cfg, nil
+	}
+
+	return nil, fmt.Errorf("no valid backup found")
+}
+
+// setBrokenAside moves the config file which can not be parsed aside, so that it is not overwritten by the next write.
+func setBrokenAside() {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	moveBroken(filepath.Join(configDir, configFile))
+}
+
+// moveBroken renames the broken file with the time it is found broken, e.g. gost.yml.broken-20060102-150405.000.
+func moveBroken(path string) {
+	name := fmt.Sprintf("%s.broken-%s", path, time.Now().Format(backupTimeFmt))
+	if err := os.Rename(path, name); err != nil {
+		slog.Error(fmt.Sprintf("move broken config: %v", err))
+		return
+	}
+	slog.Warn(fmt.Sprintf("broken config moved to %s", name))
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// logBufferSize is the number of the recent log records kept in memory.
+	logBufferSize = 1000
+)
+
+// logLevel is the level of the log, it can be changed at runtime.
+var logLevel = &slog.LevelVar{}
+
+// LogLevel returns the current level of the log.
+func LogLevel() slog.Level {
+	return logLevel.Level()
+}
+
+// SetLogLevel changes the level of the log at runtime, it is not written to the config.
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
+func parseLogLevel(s string) slog.Level {
+	switch s {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// LogRecord is a log record kept in memory.
+type LogRecord struct {
+	// Seq identifies the record, it increases with each record.
+	Seq   uint64
+	Time  time.Time
+	Level slog.Level
+	Msg   string
+	// Attrs are the attributes of the record in the form of key=value.
+	Attrs string
+}
+
+func (r *LogRecord) String() string {
+	s := fmt.Sprintf("%s %s %s", r.Time.Format("2006-01-02 15:04:05.000"), r.Level, r.Msg)
+	if r.Attrs != "" {
+		s += " " + r.Attrs
+	}
+	return s
+}
+
+// logBuffer is a ring buffer of the recent log records.
+type logBuffer struct {
+	records []LogRecord
+	// next is the index of the next record to write.
+	next int
+	full bool
+	seq  uint64
+	mu   sync.RWMutex
+}
+
+var logs = &logBuffer{
+	records: make([]LogRecord, logBufferSize),
+}
+
+func (b *logBuffer) add(r LogRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	r.Seq = b.seq
+	b.records[b.next] = r
+	b.next = (b.next + 1) % len(b.records)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Logs returns the recent log records, the most recent first.
+func Logs() []LogRecord {
+	b := logs
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := b.next
+	if b.full {
+		n = len(b.records)
+	}
+	records := make([]LogRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		records = append(records, b.records[(b.next-i+len(b.records))%len(b.records)])
+	}
+	return records
+}
+
+// LogSeq returns the sequence of the most recent log record, to tell whether there are new records.
+func LogSeq() uint64 {
+	logs.mu.RLock()
+	defer logs.mu.RUnlock()
+
+	return logs.seq
+}
+
+// bufferHandler is a slog.Handler keeping the records in the log buffer, besides passing them to the next handler.
+type bufferHandler struct {
+	next   slog.Handler
+	attrs  string
+	groups string
+}
+
+func newBufferHandler(next slog.Handler) slog.Handler {
+	return &bufferHandler{next: next}
+}
+
+func (h *bufferHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= logLevel.Level()
+}
+
+func (h *bufferHandler) Handle(ctx context.Context, r slog.Record) error {
+	var attrs strings.Builder
+	attrs.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&attrs, h.groups, a)
+		return true
+	})
+
+	logs.add(LogRecord{
+		Time:  r.Time,
+		Level: r.Level,
+		Msg:   r.Message,
+		Attrs: strings.TrimSpace(attrs.String()),
+	})
+
+	if h.next.Enabled(ctx, r.Level) {
+		return h.next.Handle(ctx, r)
+	}
+	return nil
+}
+
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		appendAttr(&b, h.groups, a)
+	}
+	return &bufferHandler{
+		next:   h.next.WithAttrs(attrs),
+		attrs:  b.String(),
+		groups: h.groups,
+	}
+}
+
+func (h *bufferHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &bufferHandler{
+		next:   h.next.WithGroup(name),
+		attrs:  h.attrs,
+		groups: h.groups + name + ".",
+	}
+}
+
+func appendAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, v := range a.Value.Group() {
+			appendAttr(b, prefix, v)
+		}
+		return
+	}
+	fmt.Fprintf(b, " %s%s=%v", prefix, a.Key, a.Value)
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+)
+
+// mergeConfig merges the config changed by the app and the one changed by others since base,
+// which is the content of the config file both are changed from.
+// The servers are matched by name, a field changed by the app is taken from local, the others from theirs.
+// A nil base takes all the fields of local.
+func mergeConfig(base, local, theirs *Config) *Config {
+	if base == nil {
+		base = &Config{}
+	}
+	// the sealed values differ each time they are sealed, they are compared in plain text.
+	base, local, theirs = openConfig(base), openConfig(local), openConfig(theirs)
+
+	merged := &Config{}
+	mergeFields(reflect.ValueOf(merged).Elem(), reflect.ValueOf(base).Elem(), reflect.ValueOf(local).Elem(), reflect.ValueOf(theirs).Elem(), "Servers", "CurrentServer")
+
+	find := func(servers []*Server, name string) *Server {
+		for _, s := range servers {
+			if s != nil && s.Name == name {
+				return s
+			}
+		}
+		return nil
+	}
+
+	for _, t := range theirs.Servers {
+		if t == nil {
+			continue
+		}
+		b, l := find(base.Servers, t.Name), find(local.Servers, t.Name)
+		switch {
+		case b == nil && l == nil:
+			// added by others.
+			merged.Servers = append(merged.Servers, t)
+		case l == nil:
+			// removed by the app, unless it is changed by others as well.
+			if !sameServer(b, t) {
+				merged.Servers = append(merged.Servers, t)
+			}
+		case b == nil:
+			// added by both, the app wins.
+			merged.Servers = append(merged.Servers, l)
+		default:
+			merged.Servers = append(merged.Servers, mergeServer(b, l, t))
+		}
+	}
+	for _, l := range local.Servers {
+		if l == nil || find(theirs.Servers, l.Name) != nil {
+			continue
+		}
+		// added by the app, or removed by others while it is changed by the app.
+		if b := find(base.Servers, l.Name); b == nil || !sameServer(b, l) {
+			merged.Servers = append(merged.Servers, l)
+		}
+	}
+
+	// the current server is kept by name, as the servers may be reordered.
+	current := theirs.currentServer()
+	if l := local.currentServer(); l != nil && (base.currentServer() == nil || l.Name != base.currentServer().Name) {
+		current = l
+	}
+	if current != nil {
+		merged.CurrentServer = max(0, slices.IndexFunc(merged.Servers, func(s *Server) bool { return s.Name == current.Name }))
+	}
+
+	return merged
+}
+
+// mergeServer merges the settings of the server field by field.
+func mergeServer(base, local, theirs *Server) *Server {
+	if sameServer(base, local) {
+		return theirs
+	}
+
+	merged := &Server{}
+	mergeFields(reflect.ValueOf(merged).Elem(), reflect.ValueOf(base).Elem(), reflect.ValueOf(local).Elem(), reflect.ValueOf(theirs).Elem())
+	return merged
+}
+
+// openConfig returns a copy of the config with the credentials and schedule values of the servers opened,
+// a server is kept as it is if they can not be opened, e.g. while the secrets are locked.
+func openConfig(cfg *Config) *Config {
+	v := *cfg
+	v.Servers = nil
+	for _, server := range cfg.Servers {
+		if server == nil {
+			continue
+		}
+		if s, err := openServer(server); err == nil {
+			server = s
+		}
+		v.Servers = append(v.Servers, server)
+	}
+	return &v
+}
+
+// mergeFields sets the exported fields of v to the field of local if it is changed from base, or of theirs otherwise.
+func mergeFields(v, base, local, theirs reflect.Value, skip ...string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || slices.Contains(skip, f.Name) {
+			continue
+		}
+		if reflect.DeepEqual(local.Field(i).Interface(), base.Field(i).Interface()) {
+			v.Field(i).Set(theirs.Field(i))
+		} else {
+			v.Field(i).Set(local.Field(i))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// Options override the settings of the config file at startup, e.g. by the command line,
+// they are not written to the config file.
+type Options struct {
+	// Dir is the directory of the app data, such as the config file, the backups and the key file.
+	Dir string
+	// File is the config file. A file name is in Dir, a path is relative to the working directory.
+	File string
+	// Server is the name of the server to use at startup.
+	Server string
+	// LogLevel overrides the level of the log.
+	LogLevel string
+	// Readonly makes all the servers read-only.
+	Readonly bool
+}
+
+type Option func(opts *Options)
+
+func WithDir(dir string) Option {
+	return func(opts *Options) {
+		opts.Dir = dir
+	}
+}
+
+func WithFile(file string) Option {
+	return func(opts *Options) {
+		opts.File = file
+	}
+}
+
+func WithServer(server string) Option {
+	return func(opts *Options) {
+		opts.Server = server
+	}
+}
+
+func WithLogLevel(level string) Option {
+	return func(opts *Options) {
+		opts.LogLevel = level
+	}
+}
+
+func WithReadonly(readonly bool) Option {
+	return func(opts *Options) {
+		opts.Readonly = readonly
+	}
+}
+
+// readonly is set by the Readonly option.
+var readonly atomic.Bool
+
+// IsReadonly reports whether the server is read-only by its setting, or by the Readonly option.
+func (s *Server) IsReadonly() bool {
+	return s.Readonly || readonly.Load()
+}
+
+// File returns the path of the config file.
+func File() string {
+	return filepath.Join(configDir, configFile)
+}
+
+// setFile sets the config directory and file by the options.
+func setFile(options *Options) {
+	if options.Dir != "" {
+		if dir, err := filepath.Abs(options.Dir); err == nil {
+			configDir = dir
+		} else {
+			configDir = options.Dir
+		}
+	}
+
+	if options.File == "" {
+		return
+	}
+	if filepath.Base(options.File) == options.File {
+		configFile = options.File
+		return
+	}
+	file, err := filepath.Abs(options.File)
+	if err != nil {
+		file = options.File
+	}
+	configDir, configFile = filepath.Dir(file), filepath.Base(file)
+}
+
+// selectServer makes the named server the current server, without writing the config.
+func selectServer(cfg *Config, name string) {
+	for i, server := range cfg.Servers {
+		if server.Name == name {
+			cfg.CurrentServer = i
+			return
+		}
+	}
+	slog.Warn(fmt.Sprintf("server %s not found", name))
+}
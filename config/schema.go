@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the version of the config layout written by the app.
+const CurrentVersion = 1
+
+// Migration upgrades the config document of the previous version to Version.
+// It works on the raw document, as the layout of the older versions can not be decoded into Config.
+type Migration struct {
+	Version int
+	Desc    string
+	Migrate func(doc map[string]any) error
+}
+
+// migrations is the registry of the migrations in ascending order of version.
+// A change of the layout, e.g. moving a setting of the servers, bumps CurrentVersion
+// and appends the migration converting the documents of the previous version.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Desc:    "add the version of the config",
+		// the unversioned config has the same layout.
+		Migrate: func(doc map[string]any) error { return nil },
+	},
+}
+
+// migrate upgrades the config document data to CurrentVersion step by step,
+// it reports the version of data and whether it is migrated.
+func migrate(data []byte) (_ []byte, version int, migrated bool, err error) {
+	doc := map[string]any{}
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return
+	}
+
+	if v, ok := doc["version"].(int); ok {
+		version = v
+	}
+	if version > CurrentVersion {
+		slog.Warn(fmt.Sprintf("config version %d is newer than %d, the unknown settings are kept", version, CurrentVersion))
+		return data, version, false, nil
+	}
+
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+
+		if err = m.Migrate(doc); err != nil {
+			err = fmt.Errorf("migrate config to version %d: %w", m.Version, err)
+			return
+		}
+		doc["version"] = m.Version
+		migrated = true
+
+		slog.Info(fmt.Sprintf("config migrated to version %d: %s", m.Version, m.Desc))
+	}
+
+	if !migrated {
+		return data, version, false, nil
+	}
+
+	data, err = yaml.Marshal(doc)
+	return data, version, true, err
+}
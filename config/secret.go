@@ -0,0 +1,479 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	keyFile = "gost.key"
+	keySize = 32
+	// kdfIterations is the PBKDF2 iteration count of the key derived from the master passphrase.
+	kdfIterations = 200000
+	// sealedPrefix marks the sealed values in the config file.
+	sealedPrefix = "enc:"
+	checkText    = "gostctl"
+)
+
+var (
+	ErrLocked         = errors.New("secrets are locked")
+	ErrBadPassphrase  = errors.New("wrong passphrase")
+	errSecretDisabled = errors.New("secrets are not configured")
+)
+
+// Secret configures the encryption of the credentials in the config file.
+// The key is derived from a master passphrase if Salt is set, or read from the key file otherwise.
+type Secret struct {
+	// Salt is the salt of the key derived from the master passphrase.
+	Salt string `yaml:",omitempty"`
+	// KeyFile is the file holding the key, it defaults to gost.key in the app data directory.
+	KeyFile string `yaml:",omitempty"`
+	// Check is a known text sealed with the key, to verify the passphrase.
+	Check string
+	// IdleTimeout forgets the key derived from the passphrase if it is not used for the duration.
+	IdleTimeout time.Duration `yaml:",omitempty"`
+}
+
+func (s *Secret) passphrase() bool {
+	return s != nil && s.Salt != ""
+}
+
+func (s *Secret) keyFile() string {
+	if s.KeyFile != "" {
+		return s.KeyFile
+	}
+	return filepath.Join(configDir, keyFile)
+}
+
+// keyring holds the unlocked key.
+var keyring struct {
+	key    []byte
+	timer  *time.Timer
+	onLock []func()
+	mu     sync.Mutex
+}
+
+// OnLock registers fn to be called when the key is forgotten after the idle timeout.
+func OnLock(fn func()) {
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+
+	keyring.onLock = append(keyring.onLock, fn)
+}
+
+// Locked reports whether the secrets need the master passphrase to be unlocked.
+func Locked() bool {
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+
+	return Get().Secret.passphrase() && keyring.key == nil
+}
+
+// Unlock derives the key from the master passphrase, and seals the plain credentials left in the config.
+func Unlock(passphrase string) error {
+	secret := Get().Secret
+	if !secret.passphrase() {
+		return errSecretDisabled
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(secret.Salt)
+	if err != nil {
+		return err
+	}
+	key := deriveKey(passphrase, salt)
+	if v, err := open(key, secret.Check); err != nil || v != checkText {
+		return ErrBadPassphrase
+	}
+
+	setKey(key, secret.IdleTimeout)
+	migrateSecrets()
+	return nil
+}
+
+// Lock forgets the unlocked key.
+func Lock() {
+	keyring.mu.Lock()
+	// the key is not cleared in place, as it may be in use by a seal or open.
+	keyring.key = nil
+	if keyring.timer != nil {
+		keyring.timer.Stop()
+		keyring.timer = nil
+	}
+	handlers := keyring.onLock
+	keyring.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn()
+	}
+}
+
+// SetPassphrase re-seals the credentials with a key derived from passphrase,
+// or with the key file if passphrase is empty. The secrets must be unlocked.
+func SetPassphrase(passphrase string, idleTimeout time.Duration) error {
+	cfg := Get()
+
+	// the servers in use are left unchanged until the config is written,
+	// the copies hold the credentials opened with the current key.
+	servers := make([]*Server, 0, len(cfg.Servers))
+	for _, server := range cfg.Servers {
+		v, err := openServer(server)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, v)
+	}
+
+	// the key of the key file is never forgotten, the idle timeout applies to the passphrase only.
+	secret := &Secret{}
+	var key []byte
+	if passphrase != "" {
+		secret.IdleTimeout = idleTimeout
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		secret.Salt = base64.StdEncoding.EncodeToString(salt)
+		key = deriveKey(passphrase, salt)
+	} else {
+		if cfg.Secret != nil {
+			secret.KeyFile = cfg.Secret.KeyFile
+		}
+		k, err := loadKeyFile(secret.keyFile())
+		if err != nil {
+			return err
+		}
+		key = k
+	}
+
+	check, err := seal(key, checkText)
+	if err != nil {
+		return err
+	}
+	secret.Check = check
+
+	for _, server := range servers {
+		if err := sealServer(server, key); err != nil {
+			return err
+		}
+	}
+
+	cfg.Secret = secret
+	cfg.Servers = servers
+	merged, err := cfg.write()
+	if err != nil {
+		return err
+	}
+	if !merged {
+		Set(cfg)
+	}
+	setKey(key, secret.IdleTimeout)
+
+	return nil
+}
+
+// SetIdleTimeout sets the idle timeout of the key derived from the master passphrase.
+func SetIdleTimeout(idleTimeout time.Duration) error {
+	cfg := Get()
+	secret := &Secret{}
+	if cfg.Secret != nil {
+		*secret = *cfg.Secret
+	}
+	secret.IdleTimeout = 0
+	if secret.passphrase() {
+		secret.IdleTimeout = idleTimeout
+	}
+	cfg.Secret = secret
+	Set(cfg)
+
+	if secret.passphrase() {
+		if key, err := currentKey(); err == nil {
+			setKey(key, idleTimeout)
+		}
+	}
+
+	return cfg.Write()
+}
+
+// Secret returns the plain password of the server, which is sealed in the config.
+func (s *Server) Secret() (string, error) {
+	return Open(s.Password)
+}
+
+// PlainValue returns the plain value of the schedule, which is sealed in the config as it may hold credentials.
+func (s *Schedule) PlainValue() (string, error) {
+	return Open(s.Value)
+}
+
+// Seal seals the plain value with the key of the config, e.g. for the credentials stored out of the config file.
+func Seal(plain string) (string, error) {
+	if plain == "" {
+		return "", nil
+	}
+
+	key, err := currentKey()
+	if err != nil {
+		return "", err
+	}
+	return seal(key, plain)
+}
+
+// Open returns the plain value of the value sealed by Seal, a value not sealed is returned as is.
+func Open(sealed string) (string, error) {
+	if !strings.HasPrefix(sealed, sealedPrefix) {
+		return sealed, nil
+	}
+
+	key, err := currentKey()
+	if err != nil {
+		return "", err
+	}
+	return open(key, sealed)
+}
+
+// initSecret sets up the key of the config, it reports whether a new key file is created for an old config file.
+func initSecret(cfg *Config) (created bool) {
+	if cfg.Secret == nil {
+		secret := &Secret{}
+		key, err := loadKeyFile(secret.keyFile())
+		if err != nil {
+			slog.Error(fmt.Sprintf("secret: %v", err))
+			return
+		}
+		if secret.Check, err = seal(key, checkText); err != nil {
+			slog.Error(fmt.Sprintf("secret: %v", err))
+			return
+		}
+		cfg.Secret = secret
+		created = true
+	}
+
+	if cfg.Secret.passphrase() {
+		return
+	}
+
+	key, err := loadKeyFile(cfg.Secret.keyFile())
+	if err != nil {
+		slog.Error(fmt.Sprintf("secret: %v", err))
+		return
+	}
+	setKey(key, 0)
+	return
+}
+
+// migrateSecrets seals the plain credentials in the config and writes it.
+func migrateSecrets() {
+	cfg := Get()
+	for _, server := range cfg.Servers {
+		if hasPlain(server) {
+			if err := cfg.Write(); err != nil {
+				slog.Error(fmt.Sprintf("seal secrets: %v", err))
+			}
+			return
+		}
+	}
+}
+
+func isPlain(v string) bool {
+	return v != "" && !strings.HasPrefix(v, sealedPrefix)
+}
+
+// sealServers returns copies of the servers with the plain credentials and schedule values sealed,
+// it is called as the config is written, the servers in use are left unchanged.
+func sealServers(servers []*Server) ([]*Server, error) {
+	var key []byte
+	sealed := make([]*Server, 0, len(servers))
+	for _, server := range servers {
+		v := server.clone()
+		v.Schedules = cloneSchedules(server.Schedules)
+
+		if key == nil && hasPlain(v) {
+			k, err := currentKey()
+			if err != nil {
+				return nil, err
+			}
+			key = k
+		}
+		if key != nil {
+			if err := sealServer(v, key); err != nil {
+				return nil, err
+			}
+		}
+		sealed = append(sealed, v)
+	}
+	return sealed, nil
+}
+
+// openServer returns a copy of the server with the credentials and schedule values opened, along with its runtime state.
+func openServer(server *Server) (*Server, error) {
+	v := server.clone()
+	v.state = server.State()
+	v.events = server.Events()
+	v.Schedules = cloneSchedules(server.Schedules)
+
+	var err error
+	if v.Password, err = server.Secret(); err != nil {
+		return nil, err
+	}
+	for _, s := range v.Schedules {
+		if s.Value, err = s.PlainValue(); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// sealServer seals the plain credentials and schedule values of the server with key in place,
+// the server must not be in use.
+func sealServer(server *Server, key []byte) (err error) {
+	if isPlain(server.Password) {
+		if server.Password, err = seal(key, server.Password); err != nil {
+			return err
+		}
+	}
+	for _, s := range server.Schedules {
+		if isPlain(s.Value) {
+			if s.Value, err = seal(key, s.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hasPlain(server *Server) bool {
+	if isPlain(server.Password) {
+		return true
+	}
+	for _, s := range server.Schedules {
+		if isPlain(s.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneSchedules(schedules []*Schedule) []*Schedule {
+	var v []*Schedule
+	for _, s := range schedules {
+		if s == nil {
+			continue
+		}
+		c := *s
+		v = append(v, &c)
+	}
+	return v
+}
+
+func setKey(key []byte, idleTimeout time.Duration) {
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+
+	keyring.key = key
+	if keyring.timer != nil {
+		keyring.timer.Stop()
+		keyring.timer = nil
+	}
+	if idleTimeout > 0 {
+		keyring.timer = time.AfterFunc(idleTimeout, Lock)
+	}
+}
+
+// currentKey returns the unlocked key, the use of the key defers the idle timeout.
+func currentKey() ([]byte, error) {
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+
+	if keyring.key == nil {
+		return nil, ErrLocked
+	}
+	if secret := Get().Secret; keyring.timer != nil && secret != nil {
+		keyring.timer.Reset(secret.IdleTimeout)
+	}
+	return keyring.key, nil
+}
+
+// loadKeyFile reads the key from the file, a new key is generated if the file does not exist.
+func loadKeyFile(name string) ([]byte, error) {
+	b, err := os.ReadFile(name)
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("invalid key file %s", name)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	os.MkdirAll(filepath.Dir(name), 0755)
+	if err := os.WriteFile(name, []byte(base64.StdEncoding.EncodeToString(key)), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func seal(key []byte, plain string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	b := aead.Seal(nonce, nonce, []byte(plain), nil)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+func open(key []byte, sealed string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(b) < aead.NonceSize() {
+		return "", errors.New("invalid sealed value")
+	}
+
+	plain, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey derives the key from the passphrase by PBKDF2 with HMAC-SHA256.
+func deriveKey(passphrase string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, kdfIterations, keySize, sha256.New)
+}
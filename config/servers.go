@@ -0,0 +1,367 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ServerLinkScheme is the scheme of the links adding a server, e.g.
+	// gostctl://add-server?name=gost&url=http://localhost:18080&interval=3s
+	ServerLinkScheme = "gostctl"
+	serverLinkAdd    = "add-server"
+)
+
+var ErrNoServers = errors.New("no servers found")
+
+// serverFile is the layout of the file of the exported servers,
+// which is a subset of the config file, so a config file can be imported as well.
+type serverFile struct {
+	Version int `yaml:"version"`
+	Servers []*Server
+}
+
+// ExportServers writes the named servers to the file, or all the servers if names is empty.
+// The sealed passwords and schedule values are written in plain text, as the key of the config is not shared,
+// or are left out if stripSecrets is true.
+func ExportServers(name string, names []string, stripSecrets bool) error {
+	f := serverFile{
+		Version: CurrentVersion,
+	}
+	for _, server := range Get().Servers {
+		if len(names) > 0 && !slices.Contains(names, server.Name) {
+			continue
+		}
+
+		v := server.clone()
+		if stripSecrets {
+			v.Password = ""
+			v.Schedules = cloneSchedules(server.Schedules)
+			for _, s := range v.Schedules {
+				s.Value = ""
+			}
+		} else {
+			var err error
+			if v, err = openServer(server); err != nil {
+				return fmt.Errorf("server %s: %w", server.Name, err)
+			}
+		}
+		f.Servers = append(f.Servers, v)
+	}
+	if len(f.Servers) == 0 {
+		return ErrNoServers
+	}
+
+	data, err := yaml.Marshal(&f)
+	if err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0644)
+	if !stripSecrets {
+		perm = 0600
+	}
+	return WriteFile(name, data, perm)
+}
+
+// ImportServers adds the servers in the file exported by ExportServers, or in a config file.
+// It returns the names of the added servers, the servers with the existing names are renamed,
+// and the names of the servers whose password has to be entered again, as it is sealed by the key of another config.
+// The schedules and the auto-save files refer to the files of the exporting system,
+// they are imported only if withLocal is true.
+func ImportServers(name string, withLocal bool) (names []string, noPassword []string, err error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, _, _, err = migrate(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var f serverFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, nil, err
+	}
+
+	servers := slices.DeleteFunc(f.Servers, func(s *Server) bool {
+		return s == nil || strings.TrimSpace(s.URL) == ""
+	})
+	if len(servers) == 0 {
+		return nil, nil, ErrNoServers
+	}
+
+	var sealed []int
+	for i, server := range servers {
+		if strings.HasPrefix(server.Password, sealedPrefix) {
+			server.Password = ""
+			sealed = append(sealed, i)
+		}
+		if !withLocal {
+			server.AutoSave = ""
+			server.Schedules = nil
+		}
+		// the schedule values sealed by another config can not be opened.
+		for _, s := range server.Schedules {
+			if s != nil && strings.HasPrefix(s.Value, sealedPrefix) {
+				s.Value = ""
+			}
+		}
+	}
+
+	if names, err = AddServers(servers...); err != nil {
+		return nil, nil, err
+	}
+	for _, i := range sealed {
+		noPassword = append(noPassword, names[i])
+	}
+	return names, noPassword, nil
+}
+
+// AddServers appends the servers to the config and writes it.
+// A server with the name of an existing one is renamed with a numeric suffix, e.g. "gost (2)".
+// It returns the names of the added servers.
+func AddServers(servers ...*Server) ([]string, error) {
+	cfg := Get()
+
+	list := slices.Clone(cfg.Servers)
+	var names []string
+	for _, server := range servers {
+		server.Name = UniqueServerName(list, server.Name)
+		list = append(list, server)
+		names = append(names, server.Name)
+	}
+	cfg.Servers = list
+
+	// the passwords are sealed by the write, the config is unchanged if they can not be.
+	merged, err := cfg.write()
+	if err != nil {
+		return nil, err
+	}
+	if !merged {
+		Set(cfg)
+	}
+	return names, nil
+}
+
+// UniqueServerName returns name, or name with the lowest numeric suffix not used by the servers.
+func UniqueServerName(servers []*Server, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "server"
+	}
+
+	exists := func(name string) bool {
+		return slices.ContainsFunc(servers, func(s *Server) bool {
+			return s != nil && s.Name == name
+		})
+	}
+	if !exists(name) {
+		return name
+	}
+	for i := 2; ; i++ {
+		if v := fmt.Sprintf("%s (%d)", name, i); !exists(v) {
+			return v
+		}
+	}
+}
+
+// ParseServerLink parses the link adding a server:
+//
+//	gostctl://add-server?name=NAME&group=GROUP&tags=TAG1,TAG2&url=URL&username=USER&interval=5s&timeout=10s&readonly=true
+//
+// The url parameter is required. The durations are in Go syntax, or in seconds if they have no unit.
+// A link is opened from untrusted places, so the password is not taken from it but entered by the user,
+// and the auto-save file is not set by it, as it writes to the local file system.
+func ParseServerLink(link string) (*Server, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != ServerLinkScheme {
+		return nil, fmt.Errorf("invalid link scheme %q", u.Scheme)
+	}
+	// the action is the host of gostctl://add-server, or the path of gostctl:add-server.
+	if action := strings.Trim(u.Host+u.Opaque+u.Path, "/"); action != serverLinkAdd {
+		return nil, fmt.Errorf("unknown link action %q", action)
+	}
+
+	q := u.Query()
+	server := &Server{
+		Name:     strings.TrimSpace(q.Get("name")),
+		Group:    strings.TrimSpace(q.Get("group")),
+		Tags:     ParseTags(q.Get("tags")),
+		URL:      strings.TrimSpace(q.Get("url")),
+		Username: q.Get("username"),
+	}
+	if server.URL == "" {
+		return nil, errors.New("missing server url")
+	}
+	if _, err := url.Parse(server.URL); err != nil {
+		return nil, fmt.Errorf("invalid server url: %w", err)
+	}
+	if server.Name == "" {
+		if v, err := url.Parse(server.URL); err == nil && v.Host != "" {
+			server.Name = v.Host
+		} else {
+			server.Name = server.URL
+		}
+	}
+
+	if server.Interval, err = parseLinkDuration(q.Get("interval")); err != nil {
+		return nil, fmt.Errorf("invalid interval: %w", err)
+	}
+	if server.Timeout, err = parseLinkDuration(q.Get("timeout")); err != nil {
+		return nil, fmt.Errorf("invalid timeout: %w", err)
+	}
+	if v := q.Get("readonly"); v != "" {
+		if server.Readonly, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid readonly: %w", err)
+		}
+	}
+
+	return server, nil
+}
+
+// ServerLink returns the link adding the server, the password and the auto-save file are left out.
+func ServerLink(s *Server) string {
+	q := url.Values{}
+	q.Set("name", s.Name)
+	q.Set("url", s.URL)
+	if s.Group != "" {
+		q.Set("group", s.Group)
+	}
+	if len(s.Tags) > 0 {
+		q.Set("tags", strings.Join(s.Tags, ","))
+	}
+	if s.Username != "" {
+		q.Set("username", s.Username)
+	}
+	if s.Interval > 0 {
+		q.Set("interval", s.Interval.String())
+	}
+	if s.Timeout > 0 {
+		q.Set("timeout", s.Timeout.String())
+	}
+	if s.Readonly {
+		q.Set("readonly", "true")
+	}
+
+	u := url.URL{
+		Scheme:   ServerLinkScheme,
+		Host:     serverLinkAdd,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+func parseLinkDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// clone returns a copy of the server settings, without the runtime state.
+func (s *Server) clone() *Server {
+	return &Server{
+		Name:               s.Name,
+		Group:              s.Group,
+		Tags:               s.Tags,
+		URL:                s.URL,
+		Username:           s.Username,
+		Password:           s.Password,
+		Interval:           s.Interval,
+		BackgroundInterval: s.BackgroundInterval,
+		Timeout:            s.Timeout,
+		AutoSave:           s.AutoSave,
+		Readonly:           s.Readonly,
+		Schedules:          s.Schedules,
+		Extra:              s.Extra,
+	}
+}
+
+// ParseTags splits the comma separated tags, the empty and duplicate tags are dropped.
+func ParseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || slices.ContainsFunc(tags, func(v string) bool { return strings.EqualFold(v, tag) }) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// HasTag reports whether the server has the tag, the tags are case-insensitive.
+func (s *Server) HasTag(tag string) bool {
+	return slices.ContainsFunc(s.Tags, func(v string) bool {
+		return strings.EqualFold(v, tag)
+	})
+}
+
+// Match reports whether the server matches all the whitespace separated terms of the search query.
+// A term "tag:NAME" or "group:NAME" matches the tag or the group of the server,
+// the other terms match a part of the name, URL, group or a tag, case-insensitively.
+func (s *Server) Match(query string) bool {
+	for _, term := range strings.Fields(query) {
+		if v, ok := strings.CutPrefix(term, "tag:"); ok {
+			if !s.HasTag(v) {
+				return false
+			}
+			continue
+		}
+		if v, ok := strings.CutPrefix(term, "group:"); ok {
+			if !strings.EqualFold(s.Group, v) {
+				return false
+			}
+			continue
+		}
+
+		term = strings.ToLower(term)
+		if !strings.Contains(strings.ToLower(s.Name), term) &&
+			!strings.Contains(strings.ToLower(s.URL), term) &&
+			!strings.Contains(strings.ToLower(s.Group), term) &&
+			!slices.ContainsFunc(s.Tags, func(tag string) bool { return strings.Contains(strings.ToLower(tag), term) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// ServersByTag returns the servers with any of the tags, e.g. to select the servers to export or copy the links of by tag.
+func ServersByTag(tags ...string) []*Server {
+	var servers []*Server
+	for _, server := range Get().Servers {
+		if slices.ContainsFunc(tags, server.HasTag) {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// ServerTags returns the tags of all the servers in order.
+func ServerTags() []string {
+	var tags []string
+	for _, server := range Get().Servers {
+		for _, tag := range server.Tags {
+			if !slices.ContainsFunc(tags, func(v string) bool { return strings.EqualFold(v, tag) }) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
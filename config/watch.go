@@ -0,0 +1,219 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// watchInterval is the interval of checking the config file for the changes by others.
+const watchInterval = 2 * time.Second
+
+var ErrConflict = errors.New("config file is changed by others")
+
+// ChangeEvent reports the config reloaded after the config file is changed by others.
+type ChangeEvent struct {
+	// ServerChanged reports whether the settings of the current server are changed.
+	ServerChanged bool
+	// Conflict is the file keeping the config of a write rejected due to the change, if any.
+	Conflict string
+	// Merged reports whether the config of the rejected write is merged into the changed file,
+	// otherwise it is only kept in the Conflict file.
+	Merged bool
+}
+
+type fileStat struct {
+	modTime time.Time
+	size    int64
+}
+
+func statOf(fi os.FileInfo) fileStat {
+	return fileStat{modTime: fi.ModTime(), size: fi.Size()}
+}
+
+// file tracks the content of the config file last read or written by the app.
+var file struct {
+	known bool
+	stat  fileStat
+	sum   [sha256.Size]byte
+	// data is the content of the config file, the base of merging a write rejected due to a change.
+	data []byte
+	// broken is the stat of the changed file failed to parse, which is not parsed again.
+	broken   fileStat
+	onChange []func(ChangeEvent)
+	mu       sync.Mutex
+}
+
+// OnChange registers fn to be called when the config is reloaded from the file changed by others.
+func OnChange(fn func(e ChangeEvent)) {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	file.onChange = append(file.onChange, fn)
+}
+
+// remember records data as the content of the config file known to the app.
+// The caller must hold file.mu.
+func remember(data []byte) {
+	fi, err := os.Stat(filepath.Join(configDir, configFile))
+	if err != nil {
+		return
+	}
+	file.known = true
+	file.stat = statOf(fi)
+	file.sum = sha256.Sum256(data)
+	file.data = data
+}
+
+// changed reads the config file if its content is changed since it is known to the app.
+// The caller must hold file.mu.
+func changed() ([]byte, fileStat, bool) {
+	fi, err := os.Stat(filepath.Join(configDir, configFile))
+	// a removed file is written again by the app.
+	if err != nil || !file.known || statOf(fi) == file.stat {
+		return nil, fileStat{}, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, configFile))
+	if err != nil {
+		return nil, fileStat{}, false
+	}
+	if sha256.Sum256(data) == file.sum {
+		// the file is touched only.
+		file.stat = statOf(fi)
+		return nil, fileStat{}, false
+	}
+	return data, statOf(fi), true
+}
+
+// watch polls the config file and reloads the config when it is changed by others.
+func watch() {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reload("")
+	}
+}
+
+// reload loads the changed config file into the config, keeping the runtime states of the servers.
+// The conflict is the file of the rejected write which causes the reload, it is reported along with the change.
+func reload(conflict string) {
+	file.mu.Lock()
+	data, stat, ok := changed()
+	if !ok || (stat == file.broken && conflict == "") {
+		file.mu.Unlock()
+		return
+	}
+
+	handlers := file.onChange
+	cfg, err := parseConfig(data)
+	if err != nil {
+		file.broken = stat
+		file.mu.Unlock()
+		slog.Warn(fmt.Sprintf("reload config: %v", err))
+
+		// the config is kept until the file is fixed.
+		if conflict != "" {
+			for _, fn := range handlers {
+				fn(ChangeEvent{Conflict: conflict})
+			}
+		}
+		return
+	}
+	remember(data)
+	file.mu.Unlock()
+
+	apply(cfg, handlers, ChangeEvent{Conflict: conflict})
+}
+
+// apply sets cfg loaded from the changed config file as the config, keeping the runtime states of the servers,
+// and notifies the handlers of the change e.
+func apply(cfg *Config, handlers []func(ChangeEvent), e ChangeEvent) {
+	old := Get()
+	for _, server := range cfg.Servers {
+		for _, v := range old.Servers {
+			if v.Name == server.Name {
+				server.state = v.State()
+				server.events = v.Events()
+				break
+			}
+		}
+	}
+
+	// the credentials written by others in plain text are sealed with the current key on the next write.
+	locked := false
+	if cfg.Secret == nil {
+		cfg.Secret = old.Secret
+	} else if !reflect.DeepEqual(cfg.Secret, old.Secret) {
+		if cfg.Secret.passphrase() {
+			locked = true
+		} else {
+			initSecret(cfg)
+		}
+	}
+	Set(cfg)
+	if locked {
+		Lock()
+	}
+
+	slog.Info("config reloaded")
+
+	e.ServerChanged = !sameServer(old.currentServer(), cfg.currentServer())
+	for _, fn := range handlers {
+		fn(e)
+	}
+}
+
+// conflict keeps the config data of local rejected by a write beside the config file,
+// and merges local into the changed config file, see mergeConfig.
+// The config is reloaded without the merge if the changed file can not be parsed.
+// It reports whether the merged config is set as the config.
+func conflict(local *Config, data []byte) (merged bool, err error) {
+	name := fmt.Sprintf("%s.conflict-%s", filepath.Join(configDir, configFile), time.Now().Format(backupTimeFmt))
+	if err := WriteFile(name, data, 0600); err != nil {
+		return false, err
+	}
+	slog.Warn(fmt.Sprintf("config file is changed by others, the config is kept in %s", name))
+
+	file.mu.Lock()
+	changedData, _, ok := changed()
+	if !ok {
+		// the file is changed back meanwhile.
+		file.mu.Unlock()
+		return local.write()
+	}
+	theirs, err := parseConfig(changedData)
+	if err != nil {
+		file.mu.Unlock()
+		reload(name)
+		return false, fmt.Errorf("%w, the config is kept in %s", ErrConflict, name)
+	}
+	base, _ := parseConfig(file.data)
+	remember(changedData)
+	handlers := file.onChange
+	file.mu.Unlock()
+
+	apply(mergeConfig(base, local, theirs), handlers, ChangeEvent{Conflict: name, Merged: true})
+
+	_, err = Get().write()
+	return true, err
+}
+
+func sameServer(a, b *Server) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	va, _ := yaml.Marshal(a)
+	vb, _ := yaml.Marshal(b)
+	return bytes.Equal(va, vb)
+}
@@ -8,7 +8,9 @@ require (
 	gioui.org v0.6.0
 	gioui.org/x v0.6.1
 	github.com/google/uuid v1.6.0
+	golang.org/x/crypto v0.22.0
 	golang.org/x/exp/shiny v0.0.0-20240103183307-be819d1f06fc
+	golang.org/x/text v0.14.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -21,5 +23,4 @@ require (
 	golang.org/x/exp v0.0.0-20240314144324-c7f7c6466f7f // indirect
 	golang.org/x/image v0.14.0 // indirect
 	golang.org/x/sys v0.19.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 )
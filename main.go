@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
 	_ "net"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"gioui.org/app"
@@ -21,6 +27,9 @@ import (
 	_ "github.com/go-gost/gostctl/winres"
 )
 
+// links are the server links passed as arguments, opened once the UI is created.
+var links []string
+
 func main() {
 	Init()
 
@@ -37,6 +46,12 @@ func main() {
 func run() error {
 	ui := ui.NewUI()
 
+	for _, link := range links {
+		if err := ui.OpenLink(link); err != nil {
+			slog.Error(fmt.Sprintf("open link: %v", err))
+		}
+	}
+
 	go handleEvent(ui)
 
 	w := ui.Window()
@@ -44,7 +59,15 @@ func run() error {
 	for {
 		switch e := w.Event().(type) {
 		case app.DestroyEvent:
+			// let the pending tasks, e.g. saving a service, finish before exit.
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := runner.Shutdown(ctx); err != nil {
+				slog.Warn(fmt.Sprintf("runner shutdown: %v", err))
+			}
 			return e.Err
+		case app.ConfigEvent:
+			util.SetWindowState(windowState(e.Config))
 		case app.FrameEvent:
 			gtx := app.NewContext(&ops, e)
 			ui.Layout(gtx)
@@ -53,7 +76,24 @@ func run() error {
 	}
 }
 
+func windowState(cfg app.Config) util.WindowState {
+	switch {
+	case cfg.Mode == app.Minimized:
+		return util.WindowHidden
+	// the app on Android loses the focus when it is sent to the background.
+	case !cfg.Focused && runtime.GOOS == "android":
+		return util.WindowHidden
+	case !cfg.Focused:
+		return util.WindowUnfocused
+	default:
+		return util.WindowFocused
+	}
+}
+
 func handleEvent(ui *ui.UI) {
+	sub := runner.Subscribe(nil)
+	defer sub.Unsubscribe()
+
 	for {
 		select {
 		case e := <-ui.Router().Event():
@@ -63,7 +103,7 @@ func handleEvent(ui *ui.UI) {
 				ui.Window().Option(app.StatusColor(theme.Current().Material.Bg))
 			}
 
-		case e := <-runner.Event():
+		case e := <-sub.Event():
 			switch e.TaskID {
 			case runner.TaskGetConfig:
 				server := config.CurrentServer()
@@ -80,11 +120,14 @@ func handleEvent(ui *ui.UI) {
 					})
 				} else {
 					server.SetState(config.ServerReady)
+					util.ReplayQueue()
 				}
 				ui.Window().Invalidate()
 
 			default:
-				if e.Err != nil {
+				// the failed step of a plan is reported by the event of the plan itself,
+				// the task canceled is replaced by another one or stopped on exit.
+				if e.Err != nil && !e.Inline && e.Progress == nil && !errors.Is(e.Err, context.Canceled) {
 					slog.Error(fmt.Sprintf("task: %s", e.Err), "task", e.TaskID)
 					ui.Router().Notify(widget.Message{
 						Type:    widget.Error,
@@ -97,7 +140,89 @@ func handleEvent(ui *ui.UI) {
 }
 
 func Init() {
-	config.Init()
+	config.Init(parseFlags()...)
 
 	util.RestartGetConfigTask()
 }
+
+// parseFlags returns the config options by the command-line flags, or by the environment variables if the flags are not set.
+//
+//	-dir DIR          GOSTCTL_DIR        directory of the app data, e.g. a portable install or a profile
+//	-config FILE      GOSTCTL_CONFIG     config file, a file name is in the app data directory
+//	-server NAME      GOSTCTL_SERVER     server to use at startup
+//	-log-level LEVEL  GOSTCTL_LOG_LEVEL  log level: debug, info, warn or error
+//	-readonly         GOSTCTL_READONLY   make all the servers read-only
+func parseFlags() []config.Option {
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	dir := fs.String("dir", os.Getenv("GOSTCTL_DIR"), "directory of the app data")
+	file := fs.String("config", os.Getenv("GOSTCTL_CONFIG"), "config file, a file name is in the app data directory")
+	server := fs.String("server", os.Getenv("GOSTCTL_SERVER"), "server to use at startup")
+	logLevel := fs.String("log-level", os.Getenv("GOSTCTL_LOG_LEVEL"), "log level: debug, info, warn or error")
+	readonly, _ := strconv.ParseBool(os.Getenv("GOSTCTL_READONLY"))
+	fs.BoolVar(&readonly, "readonly", readonly, "make all the servers read-only")
+
+	// the unknown arguments passed by the system, e.g. -psn_0_12345 on macOS, are ignored,
+	// the parsing goes on with the rest of the arguments.
+	args, ignored := knownArgs(fs, os.Args[1:])
+	if len(ignored) > 0 {
+		slog.Warn(fmt.Sprintf("ignore unknown arguments: %s", strings.Join(ignored, " ")))
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		slog.Warn(fmt.Sprintf("parse flags: %v", err))
+	}
+
+	// the system passes the server link opened with the app as an argument.
+	for _, arg := range fs.Args() {
+		if strings.HasPrefix(arg, config.ServerLinkScheme+":") {
+			links = append(links, arg)
+		}
+	}
+
+	return []config.Option{
+		config.WithDir(*dir),
+		config.WithFile(*file),
+		config.WithServer(*server),
+		config.WithLogLevel(*logLevel),
+		config.WithReadonly(readonly),
+	}
+}
+
+// knownArgs splits the arguments into the ones of the flags defined in fs, followed by the positional arguments,
+// and the unknown flags.
+func knownArgs(fs *flag.FlagSet, args []string) (known []string, unknown []string) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		f := fs.Lookup(name)
+		if f == nil {
+			// the help flags are handled by the parsing.
+			if name == "h" || name == "help" {
+				known = append(known, arg)
+			} else {
+				unknown = append(unknown, arg)
+			}
+			continue
+		}
+		known = append(known, arg)
+
+		// the value of a non-boolean flag may be the next argument.
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); !hasValue && !(ok && bf.IsBoolFlag()) && i+1 < len(args) {
+			i++
+			known = append(known, args[i])
+		}
+	}
+	return append(append(known, "--"), positional...), unknown
+}
@@ -169,4 +169,159 @@ var en_US = dict{
 	Theme:    "Theme",
 	Light:    "Light",
 	Dark:     "Dark",
+
+	ServerSettings:     "Server settings",
+	ServerSettingsHint: "The API cannot change these settings at runtime. Copy the generated config into the server's config file and restart gost.",
+	API:                "API",
+	Metrics:            "Metrics",
+	Profiling:          "Profiling",
+	Log:                "Log",
+	PathPrefix:         "Path prefix",
+	AccessLog:          "Access log",
+	LogOutput:          "Output",
+	LogOutputHint:      "stderr, stdout, none or a file path",
+	LogLevel:           "Level",
+	LogFormat:          "Format",
+	LogRotation:        "Rotation",
+	LogMaxSize:         "Max size (MB)",
+	LogMaxAge:          "Max age (days)",
+	LogMaxBackups:      "Max backups",
+	LogLocalTime:       "Local time",
+	LogCompress:        "Compress",
+	WarnAPILockout:     "This change affects the API that gostctl connects to and may lock gostctl out of this server.",
+
+	TLSMinVersion:     "Min version",
+	TLSMaxVersion:     "Max version",
+	TLSCipherSuites:   "Cipher suites",
+	TLSCipherInsecure: "insecure",
+	TLSAutoCert:       "Auto-generated certificate",
+	TLSAutoCertHint:   "used when no certificate file is set",
+	TLSValidity:       "Validity (days)",
+	TLSCommonName:     "Common name",
+	TLSOrganization:   "Organization",
+
+	ChainGroup: "Chain group",
+	Chains:     "Chains",
+
+	HTTPHeaders:        "Headers",
+	HTTPHeader:         "Header",
+	HTTPURLRewrite:     "URL rewrite",
+	RewriteMatch:       "Match (regular expression)",
+	RewriteReplacement: "Replacement",
+	RewriteTest:        "Test URL",
+	RewriteTestHint:    "e.g. http://example.com/api/v1/users",
+	RewriteNoMatch:     "No rule matches",
+	RewriteMatched:     "Matched rule",
+	DeleteHeader:       "Delete header?",
+	DeleteRewrite:      "Delete rewrite rule?",
+
+	Migration:     "Deprecated fields",
+	MigrationHint: "These objects still use deprecated fields. Applying updates them on the server in the current format.",
+	MigrationNone: "No deprecated fields found",
+
+	Saving: "Saving...",
+
+	Activity:     "Activity",
+	ActivityNone: "No activity yet",
+	Retry:        "Retry",
+	Plan:         "Plan",
+
+	Pending:            "Pending",
+	PendingChanges:     "Pending changes",
+	PendingChangesHint: "These changes were made while the server was unreachable. They are sent in order once it is back online.",
+	PendingChangesNone: "No pending changes",
+	Discard:            "Discard",
+	Overwrite:          "Overwrite",
+
+	ServiceConflict:     "Service changed on the server",
+	ServiceConflictHint: "Someone else changed this service after you opened it. Merge keeps their changes to the fields you did not edit, Overwrite replaces the service with yours.",
+	Merge:               "Merge",
+
+	Schedules:          "Schedules",
+	ScheduleNone:       "No schedules",
+	DeleteSchedule:     "Delete schedule?",
+	Cron:               "Cron",
+	CronHint:           "minute hour day month weekday, e.g. 0 9 * * 1-5",
+	NextRun:            "Next run",
+	ScheduleAction:     "Action",
+	ScheduleCreate:     "Create",
+	ScheduleUpdate:     "Update",
+	ScheduleDelete:     "Delete",
+	ScheduleSave:       "Save config",
+	ScheduleKind:       "Resource",
+	ScheduleTarget:     "Target",
+	ScheduleTargetHint: "name of the object, or file path to save the config to",
+	ScheduleValue:      "Value",
+	ScheduleValueHint:  "object in YAML or JSON",
+	ScheduleLoad:       "Load current",
+	ScheduleEnabled:    "Enabled",
+
+	ErrNotFound: "Object not found",
+
+	BackgroundInterval:     "Background interval",
+	BackgroundIntervalHint: "the period while the window is hidden, 0 to pause",
+
+	Unlock:               "Unlock",
+	UnlockHint:           "Enter the master passphrase to unlock the server credentials.",
+	ErrBadPassphrase:     "Wrong passphrase",
+	MasterPassphrase:     "Master passphrase",
+	MasterPassphraseHint: "The server credentials are encrypted with the passphrase, leave it empty to use the key file instead.",
+	Passphrase:           "Passphrase",
+	SecretKeyFile:        "Key file",
+	IdleLock:             "Lock when idle",
+	Never:                "Never",
+
+	ServerTransfer:  "Import / Export servers",
+	Servers:         "Servers",
+	AllServers:      "All servers",
+	Export:          "Export",
+	Import:          "Import",
+	StripSecrets:    "Strip secrets",
+	ServerLink:      "Server link",
+	ServerLinkHint:  "gostctl://add-server?url=...",
+	AddServer:       "Add server",
+	CopyLink:        "Copy link",
+	ServersExported: "Servers exported",
+	ServersImported: "Servers imported",
+	LinkCopied:      "Link copied",
+
+	ErrFilePathRequired: "File path is required",
+
+	Group:        "Group",
+	Tags:         "Tags",
+	TagsHint:     "separated by commas",
+	Ungrouped:    "Ungrouped",
+	SearchServer: "Search by name, URL, group or tag:NAME",
+	ServerNone:   "No matching servers",
+
+	ConfigReloaded: "Config file is changed by others and reloaded",
+	ConfigConflict: "Config file is changed by others, the unsaved changes are kept in",
+
+	Logs:       "Logs",
+	LogsNone:   "No logs",
+	SearchLog:  "Search logs",
+	LogsCopied: "Logs copied",
+	Paused:     "Paused",
+
+	MetadataUnknownKey: "Unknown key of the type, it may be ignored",
+	MetadataDefault:    "Default",
+	ErrMetadataType:    "Invalid value, the type is",
+	ErrMetadataValues:  "Invalid value, it is one of",
+
+	ServiceDeleted:     "Service deleted on the server",
+	ServiceDeletedHint: "Someone else deleted this service after you opened it. Recreate creates it again with your changes.",
+	Recreate:           "Recreate",
+
+	ImportLocalSettings: "Import schedules and auto-save files",
+	PasswordReenter:     "The passwords are sealed by another config, enter them again for",
+
+	ErrSaveCanceled: "The save was canceled before it completed, the service may not be saved",
+
+	ErrAddrRequired: "Address is required",
+
+	LinkPasswordHint: "The link does not carry the password, enter it to sign in to the server",
+
+	MigrationRollback: "Rolling back...",
+
+	ConfigMerged: "Config file is changed by others, your changes are merged into it, the config before the merge is kept in",
 }
@@ -221,6 +221,161 @@ const (
 	Light    Key = "light"
 	Dark     Key = "dark"
 
+	ServerSettings     Key = "serverSettings"
+	ServerSettingsHint Key = "serverSettingsHint"
+	API                Key = "api"
+	Metrics            Key = "metrics"
+	Profiling          Key = "profiling"
+	Log                Key = "log"
+	PathPrefix         Key = "pathPrefix"
+	AccessLog          Key = "accessLog"
+	LogOutput          Key = "logOutput"
+	LogOutputHint      Key = "logOutputHint"
+	LogLevel           Key = "logLevel"
+	LogFormat          Key = "logFormat"
+	LogRotation        Key = "logRotation"
+	LogMaxSize         Key = "logMaxSize"
+	LogMaxAge          Key = "logMaxAge"
+	LogMaxBackups      Key = "logMaxBackups"
+	LogLocalTime       Key = "logLocalTime"
+	LogCompress        Key = "logCompress"
+	WarnAPILockout     Key = "warnAPILockout"
+
+	TLSMinVersion     Key = "tlsMinVersion"
+	TLSMaxVersion     Key = "tlsMaxVersion"
+	TLSCipherSuites   Key = "tlsCipherSuites"
+	TLSCipherInsecure Key = "tlsCipherInsecure"
+	TLSAutoCert       Key = "tlsAutoCert"
+	TLSAutoCertHint   Key = "tlsAutoCertHint"
+	TLSValidity       Key = "tlsValidity"
+	TLSCommonName     Key = "tlsCommonName"
+	TLSOrganization   Key = "tlsOrganization"
+
+	ChainGroup Key = "chainGroup"
+	Chains     Key = "chains"
+
+	HTTPHeaders        Key = "httpHeaders"
+	HTTPHeader         Key = "httpHeader"
+	HTTPURLRewrite     Key = "httpURLRewrite"
+	RewriteMatch       Key = "rewriteMatch"
+	RewriteReplacement Key = "rewriteReplacement"
+	RewriteTest        Key = "rewriteTest"
+	RewriteTestHint    Key = "rewriteTestHint"
+	RewriteNoMatch     Key = "rewriteNoMatch"
+	RewriteMatched     Key = "rewriteMatched"
+	DeleteHeader       Key = "deleteHeader"
+	DeleteRewrite      Key = "deleteRewrite"
+
+	Migration     Key = "migration"
+	MigrationHint Key = "migrationHint"
+	MigrationNone Key = "migrationNone"
+
+	Saving Key = "saving"
+
+	Activity     Key = "activity"
+	ActivityNone Key = "activityNone"
+	Retry        Key = "retry"
+	Plan         Key = "plan"
+
+	Pending            Key = "pending"
+	PendingChanges     Key = "pendingChanges"
+	PendingChangesHint Key = "pendingChangesHint"
+	PendingChangesNone Key = "pendingChangesNone"
+	Discard            Key = "discard"
+	Overwrite          Key = "overwrite"
+
+	ServiceConflict     Key = "serviceConflict"
+	ServiceConflictHint Key = "serviceConflictHint"
+	Merge               Key = "merge"
+
+	Schedules          Key = "schedules"
+	ScheduleNone       Key = "scheduleNone"
+	DeleteSchedule     Key = "deleteSchedule"
+	Cron               Key = "cron"
+	CronHint           Key = "cronHint"
+	NextRun            Key = "nextRun"
+	ScheduleAction     Key = "scheduleAction"
+	ScheduleCreate     Key = "scheduleCreate"
+	ScheduleUpdate     Key = "scheduleUpdate"
+	ScheduleDelete     Key = "scheduleDelete"
+	ScheduleSave       Key = "scheduleSave"
+	ScheduleKind       Key = "scheduleKind"
+	ScheduleTarget     Key = "scheduleTarget"
+	ScheduleTargetHint Key = "scheduleTargetHint"
+	ScheduleValue      Key = "scheduleValue"
+	ScheduleValueHint  Key = "scheduleValueHint"
+	ScheduleLoad       Key = "scheduleLoad"
+	ScheduleEnabled    Key = "scheduleEnabled"
+
+	ErrNotFound Key = "errNotFound"
+
+	BackgroundInterval     Key = "backgroundInterval"
+	BackgroundIntervalHint Key = "backgroundIntervalHint"
+
+	Unlock               Key = "unlock"
+	UnlockHint           Key = "unlockHint"
+	ErrBadPassphrase     Key = "errBadPassphrase"
+	MasterPassphrase     Key = "masterPassphrase"
+	MasterPassphraseHint Key = "masterPassphraseHint"
+	Passphrase           Key = "passphrase"
+	SecretKeyFile        Key = "secretKeyFile"
+	IdleLock             Key = "idleLock"
+	Never                Key = "never"
+
+	ServerTransfer  Key = "serverTransfer"
+	Servers         Key = "servers"
+	AllServers      Key = "allServers"
+	Export          Key = "export"
+	Import          Key = "import"
+	StripSecrets    Key = "stripSecrets"
+	ServerLink      Key = "serverLink"
+	ServerLinkHint  Key = "serverLinkHint"
+	AddServer       Key = "addServer"
+	CopyLink        Key = "copyLink"
+	ServersExported Key = "serversExported"
+	ServersImported Key = "serversImported"
+	LinkCopied      Key = "linkCopied"
+
+	ErrFilePathRequired Key = "errFilePathRequired"
+
+	Group        Key = "group"
+	Tags         Key = "tags"
+	TagsHint     Key = "tagsHint"
+	Ungrouped    Key = "ungrouped"
+	SearchServer Key = "searchServer"
+	ServerNone   Key = "serverNone"
+
+	ConfigReloaded Key = "configReloaded"
+	ConfigConflict Key = "configConflict"
+
+	Logs       Key = "logs"
+	LogsNone   Key = "logsNone"
+	SearchLog  Key = "searchLog"
+	LogsCopied Key = "logsCopied"
+	Paused     Key = "paused"
+
+	MetadataUnknownKey Key = "metadataUnknownKey"
+	MetadataDefault    Key = "metadataDefault"
+	ErrMetadataType    Key = "errMetadataType"
+	ErrMetadataValues  Key = "errMetadataValues"
+
+	ServiceDeleted     Key = "serviceDeleted"
+	ServiceDeletedHint Key = "serviceDeletedHint"
+	Recreate           Key = "recreate"
+
+	ImportLocalSettings Key = "importLocalSettings"
+	PasswordReenter     Key = "passwordReenter"
+
+	ErrSaveCanceled Key = "errSaveCanceled"
+
+	ErrAddrRequired Key = "errAddrRequired"
+
+	LinkPasswordHint Key = "linkPasswordHint"
+
+	MigrationRollback Key = "migrationRollback"
+
+	ConfigMerged Key = "configMerged"
+
 	HandlerAutoDesc   Key = "handlerAutoDesc"
 	HandlerHTTPDesc   Key = "handlerHTTPDesc"
 	HandlerSOCKS4Desc Key = "handlerSOCKS4Desc"
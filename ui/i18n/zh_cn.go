@@ -173,4 +173,159 @@ var zh_CN = dict{
 	HandlerAutoDesc:   "自动识别协议类型：HTTP，SOCKS4，SOCKS5",
 	HandlerHTTPDesc:   "HTTP代理",
 	HandlerSOCKS4Desc: "SOCKS4代理",
+
+	ServerSettings:     "服务器设置",
+	ServerSettingsHint: "API无法在运行时修改这些设置，请将生成的配置复制到服务器的配置文件中并重启gost。",
+	API:                "API",
+	Metrics:            "监控指标",
+	Profiling:          "性能分析",
+	Log:                "日志",
+	PathPrefix:         "路径前缀",
+	AccessLog:          "访问日志",
+	LogOutput:          "输出",
+	LogOutputHint:      "stderr，stdout，none或文件路径",
+	LogLevel:           "级别",
+	LogFormat:          "格式",
+	LogRotation:        "日志轮转",
+	LogMaxSize:         "最大文件大小(MB)",
+	LogMaxAge:          "最长保留天数",
+	LogMaxBackups:      "最多备份数",
+	LogLocalTime:       "使用本地时间",
+	LogCompress:        "压缩",
+	WarnAPILockout:     "此修改会影响gostctl所连接的API，可能导致gostctl无法再访问此服务器。",
+
+	TLSMinVersion:     "最低版本",
+	TLSMaxVersion:     "最高版本",
+	TLSCipherSuites:   "密码套件",
+	TLSCipherInsecure: "不安全",
+	TLSAutoCert:       "自动生成证书",
+	TLSAutoCertHint:   "未设置证书文件时使用",
+	TLSValidity:       "有效期(天)",
+	TLSCommonName:     "通用名称(CN)",
+	TLSOrganization:   "组织(O)",
+
+	ChainGroup: "转发链组",
+	Chains:     "转发链列表",
+
+	HTTPHeaders:        "请求头",
+	HTTPHeader:         "请求头",
+	HTTPURLRewrite:     "URL重写",
+	RewriteMatch:       "匹配(正则表达式)",
+	RewriteReplacement: "替换为",
+	RewriteTest:        "测试URL",
+	RewriteTestHint:    "例如: http://example.com/api/v1/users",
+	RewriteNoMatch:     "没有匹配的规则",
+	RewriteMatched:     "匹配规则",
+	DeleteHeader:       "删除请求头？",
+	DeleteRewrite:      "删除重写规则？",
+
+	Migration:     "已弃用字段",
+	MigrationHint: "以下对象仍在使用已弃用的字段，应用后将以新格式更新到服务器。",
+	MigrationNone: "未发现已弃用的字段",
+
+	Saving: "正在保存...",
+
+	Activity:     "活动",
+	ActivityNone: "暂无活动",
+	Retry:        "重试",
+	Plan:         "计划",
+
+	Pending:            "待同步",
+	PendingChanges:     "待同步的更改",
+	PendingChangesHint: "以下更改是在服务器无法访问时做出的，服务器恢复后将按顺序发送。",
+	PendingChangesNone: "没有待同步的更改",
+	Discard:            "丢弃",
+	Overwrite:          "覆盖",
+
+	ServiceConflict:     "服务已在服务器上被修改",
+	ServiceConflictHint: "在你打开此服务后，其他人修改了它。合并会保留对方在你未编辑字段上的修改，覆盖则使用你的版本替换该服务。",
+	Merge:               "合并",
+
+	Schedules:          "计划任务",
+	ScheduleNone:       "暂无计划任务",
+	DeleteSchedule:     "删除计划任务？",
+	Cron:               "Cron表达式",
+	CronHint:           "分 时 日 月 周，例如 0 9 * * 1-5",
+	NextRun:            "下次执行",
+	ScheduleAction:     "操作",
+	ScheduleCreate:     "创建",
+	ScheduleUpdate:     "更新",
+	ScheduleDelete:     "删除",
+	ScheduleSave:       "保存配置",
+	ScheduleKind:       "资源",
+	ScheduleTarget:     "目标",
+	ScheduleTargetHint: "对象名称，或保存配置的文件路径",
+	ScheduleValue:      "内容",
+	ScheduleValueHint:  "YAML或JSON格式的对象",
+	ScheduleLoad:       "载入当前对象",
+	ScheduleEnabled:    "启用",
+
+	ErrNotFound: "对象不存在",
+
+	BackgroundInterval:     "后台周期",
+	BackgroundIntervalHint: "窗口隐藏时获取配置的周期，0为暂停",
+
+	Unlock:               "解锁",
+	UnlockHint:           "输入主密码以解锁服务器凭据。",
+	ErrBadPassphrase:     "密码错误",
+	MasterPassphrase:     "主密码",
+	MasterPassphraseHint: "服务器凭据将使用主密码加密，留空则使用密钥文件。",
+	Passphrase:           "密码",
+	SecretKeyFile:        "密钥文件",
+	IdleLock:             "空闲时锁定",
+	Never:                "从不",
+
+	ServerTransfer:  "导入 / 导出服务器",
+	Servers:         "服务器",
+	AllServers:      "全部服务器",
+	Export:          "导出",
+	Import:          "导入",
+	StripSecrets:    "去除密码",
+	ServerLink:      "服务器链接",
+	ServerLinkHint:  "gostctl://add-server?url=...",
+	AddServer:       "添加服务器",
+	CopyLink:        "复制链接",
+	ServersExported: "服务器已导出",
+	ServersImported: "服务器已导入",
+	LinkCopied:      "链接已复制",
+
+	ErrFilePathRequired: "文件路径必须填写",
+
+	Group:        "分组",
+	Tags:         "标签",
+	TagsHint:     "以逗号分隔",
+	Ungrouped:    "未分组",
+	SearchServer: "按名称、URL、分组或 tag:名称 搜索",
+	ServerNone:   "没有匹配的服务器",
+
+	ConfigReloaded: "配置文件已被外部修改并重新加载",
+	ConfigConflict: "配置文件已被外部修改，未保存的更改已保存到",
+
+	Logs:       "日志",
+	LogsNone:   "没有日志",
+	SearchLog:  "搜索日志",
+	LogsCopied: "日志已复制",
+	Paused:     "已暂停",
+
+	MetadataUnknownKey: "该类型的未知键，可能会被忽略",
+	MetadataDefault:    "默认值",
+	ErrMetadataType:    "值无效，类型应为",
+	ErrMetadataValues:  "值无效，应为以下之一",
+
+	ServiceDeleted:     "服务已在服务器上被删除",
+	ServiceDeletedHint: "在你打开此服务后，其他人删除了它。重新创建会使用你的修改再次创建该服务。",
+	Recreate:           "重新创建",
+
+	ImportLocalSettings: "导入定时任务和自动保存文件",
+	PasswordReenter:     "密码已被其他配置加密，请重新输入以下服务器的密码",
+
+	ErrSaveCanceled: "保存在完成前被取消，服务可能未保存",
+
+	ErrAddrRequired: "地址必须填写",
+
+	LinkPasswordHint: "链接中不包含密码，请输入密码以登录服务器",
+
+	MigrationRollback: "正在回滚...",
+
+	ConfigMerged: "配置文件已被外部修改，您的更改已合并到其中，合并前的配置已保存到",
 }
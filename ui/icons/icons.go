@@ -63,6 +63,11 @@ var (
 	IconAlert                = mustIcon(icons.AlertErrorOutline)
 	IconCode                 = mustIcon(icons.ActionDescription)
 	IconEvent                = mustIcon(icons.ActionEvent)
+	IconHistory              = mustIcon(icons.ActionHistory)
+	IconSchedule             = mustIcon(icons.ActionSchedule)
+	IconSearch               = mustIcon(icons.ActionSearch)
+	IconLabel                = mustIcon(icons.ActionLabelOutline)
+	IconPause                = mustIcon(icons.AVPause)
 )
 
 func mustIcon(data []byte) *widget.Icon {
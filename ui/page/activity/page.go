@@ -0,0 +1,240 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/api/runner/task"
+	"github.com/go-gost/gostctl/api/util"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+type activityPage struct {
+	readonly bool
+	router   *page.Router
+	list     widget.List
+
+	btnBack widget.Clickable
+
+	entries []runner.JournalEntry
+	// retries holds the retry buttons of the failed entries by entry sequence.
+	retries map[uint64]*widget.Clickable
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &activityPage{
+		router: r,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
+		},
+		retries: make(map[uint64]*widget.Clickable),
+	}
+}
+
+func (p *activityPage) Init(opts ...page.PageOption) {
+	p.readonly = false
+	if server := config.CurrentServer(); server != nil {
+		p.readonly = server.IsReadonly()
+	}
+}
+
+func (p *activityPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+
+	p.entries = runner.Journal()
+	p.updateRetries(gtx)
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						title := material.H6(th, i18n.Activity.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return p.layout(gtx, th)
+			})
+		}),
+	)
+}
+
+// updateRetries handles the clicked retry buttons and drops the buttons of the entries out of the journal.
+func (p *activityPage) updateRetries(gtx page.C) {
+	server := runner.Server()
+	seqs := make(map[uint64]bool, len(p.entries))
+	for i := range p.entries {
+		entry := &p.entries[i]
+		if !canRetry(entry, server) {
+			continue
+		}
+		seqs[entry.Seq] = true
+
+		btn := p.retries[entry.Seq]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			p.retries[entry.Seq] = btn
+		}
+		if btn.Clicked(gtx) && !p.readonly {
+			p.retry(entry)
+		}
+	}
+
+	for seq := range p.retries {
+		if !seqs[seq] {
+			delete(p.retries, seq)
+		}
+	}
+}
+
+// canRetry reports whether the failed entry can be executed again on the current server.
+// A step of a plan is not retried alone, which skips the rollback of the plan.
+// A version-checked task is not retried either, as it is bound to the version it failed on.
+func canRetry(entry *runner.JournalEntry, server string) bool {
+	return entry.Err != nil && entry.Task() != nil && !task.VersionChecked(entry.Task()) &&
+		entry.Plan == "" && entry.Server == server
+}
+
+func (p *activityPage) retry(entry *runner.JournalEntry) {
+	runner.Exec(context.Background(), entry.Task(), runner.WithCancel(true))
+	util.RestartGetConfigTask()
+}
+
+func (p *activityPage) layout(gtx page.C, th *page.T) page.D {
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			if len(p.entries) == 0 {
+				return material.Body1(th, i18n.ActivityNone.Value()).Layout(gtx)
+			}
+
+			return material.List(th, &p.list).Layout(gtx, len(p.entries), func(gtx page.C, index int) page.D {
+				return layout.Inset{
+					Bottom: 8,
+				}.Layout(gtx, func(gtx page.C) page.D {
+					return p.layoutEntry(gtx, th, &p.entries[index])
+				})
+			})
+		})
+	})
+}
+
+func (p *activityPage) layoutEntry(gtx page.C, th *page.T, entry *runner.JournalEntry) page.D {
+	title := string(entry.TaskID)
+	if entry.Resource != "" {
+		title += " " + entry.Resource
+	}
+
+	info := fmt.Sprintf("%s  %s  %s",
+		entry.Start.Local().Format(time.DateTime),
+		entry.Server,
+		entry.Duration.Round(time.Millisecond))
+	if entry.Plan != "" {
+		info += fmt.Sprintf("  %s: %s", i18n.Plan.Value(), entry.Plan)
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.Flex{
+				Alignment: layout.Middle,
+			}.Layout(gtx,
+				layout.Flexed(1, func(gtx page.C) page.D {
+					label := material.Body1(th, title)
+					label.Font.Weight = font.SemiBold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					btn := p.retries[entry.Seq]
+					if btn == nil || p.readonly {
+						return page.D{}
+					}
+
+					return material.ButtonLayoutStyle{
+						Background:   th.Bg,
+						CornerRadius: 18,
+						Button:       btn,
+					}.Layout(gtx, func(gtx page.C) page.D {
+						return layout.Inset{
+							Top:    4,
+							Bottom: 4,
+							Left:   16,
+							Right:  16,
+						}.Layout(gtx, func(gtx page.C) page.D {
+							label := material.Body2(th, i18n.Retry.Value())
+							label.Color = th.Fg
+							return label.Layout(gtx)
+						})
+					})
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: 4}.Layout),
+		layout.Rigid(material.Body2(th, info).Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			if entry.Summary == "" {
+				return page.D{}
+			}
+			label := material.Caption(th, entry.Summary)
+			label.MaxLines = 3
+			return layout.Inset{Top: 4}.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if entry.Err == nil {
+				return page.D{}
+			}
+			label := material.Body2(th, entry.Err.Error())
+			label.Color = color.NRGBA(colornames.Red500)
+			return layout.Inset{Top: 4}.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(layout.Spacer{Height: 8}.Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			div := component.Divider(th)
+			return div.Layout(gtx)
+		}),
+	)
+}
@@ -58,11 +58,9 @@ type autherPage struct {
 	httpURL              component.TextField
 	httpTimeout          component.TextField
 
-	pluginType          ui_widget.Selector
-	pluginAddr          component.TextField
-	pluginEnableTLS     ui_widget.Switcher
-	pluginTLSSecure     ui_widget.Switcher
-	pluginTLSServerName component.TextField
+	pluginType ui_widget.Selector
+	pluginAddr component.TextField
+	pluginTLS  *page.TLSEditor
 
 	id   string
 	perm page.Perm
@@ -160,14 +158,7 @@ func NewPage(r *page.Router) page.Page {
 				MaxLen:     128,
 			},
 		},
-		pluginEnableTLS: ui_widget.Switcher{Title: i18n.TLS},
-		pluginTLSSecure: ui_widget.Switcher{Title: i18n.VerifyServerCert},
-		pluginTLSServerName: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
+		pluginTLS: page.NewTLSEditor(r),
 
 		delDialog: ui_widget.Dialog{Title: i18n.DeleteAuther},
 	}
@@ -177,7 +168,7 @@ func NewPage(r *page.Router) page.Page {
 
 func (p *autherPage) Init(opts ...page.PageOption) {
 	if server := config.CurrentServer(); server != nil {
-		p.readonly = server.Readonly
+		p.readonly = server.IsReadonly()
 	}
 
 	var options page.PageOptions
@@ -204,7 +195,7 @@ func (p *autherPage) Init(opts ...page.PageOption) {
 		cfg := api.GetConfig()
 		for _, v := range cfg.Authers {
 			if v.Name == p.id {
-				auther = v
+				auther = v.Copy()
 				break
 			}
 		}
@@ -252,9 +243,7 @@ func (p *autherPage) Init(opts ...page.PageOption) {
 	{
 		p.pluginType.Clear()
 		p.pluginAddr.Clear()
-		p.pluginEnableTLS.SetValue(false)
-		p.pluginTLSSecure.SetValue(false)
-		p.pluginTLSServerName.Clear()
+		p.pluginTLS.Init(nil)
 
 		if auther.Plugin != nil {
 			p.mode.Value = string(page.PluginMode)
@@ -265,12 +254,7 @@ func (p *autherPage) Init(opts ...page.PageOption) {
 				}
 			}
 			p.pluginAddr.SetText(auther.Plugin.Addr)
-
-			if auther.Plugin.TLS != nil {
-				p.pluginEnableTLS.SetValue(true)
-				p.pluginTLSSecure.SetValue(auther.Plugin.TLS.Secure)
-				p.pluginTLSServerName.SetText(auther.Plugin.TLS.ServerName)
-			}
+			p.pluginTLS.Init(auther.Plugin.TLS)
 		}
 	}
 }
@@ -446,35 +430,7 @@ func (p *autherPage) layout(gtx page.C, th *page.T) page.D {
 							return p.pluginType.Layout(gtx, th)
 						}),
 						layout.Rigid(func(gtx page.C) page.D {
-							return p.pluginEnableTLS.Layout(gtx, th)
-						}),
-						layout.Rigid(func(gtx page.C) page.D {
-							if !p.pluginEnableTLS.Value() {
-								return page.D{}
-							}
-
-							return layout.Flex{
-								Axis: layout.Vertical,
-							}.Layout(gtx,
-								layout.Rigid(func(gtx page.C) page.D {
-									return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
-										return layout.Flex{
-											Axis: layout.Vertical,
-										}.Layout(gtx,
-											layout.Rigid(func(gtx page.C) page.D {
-												return p.pluginTLSSecure.Layout(gtx, th)
-											}),
-
-											layout.Rigid(func(gtx page.C) page.D {
-												return material.Body1(th, i18n.ServerName.Value()).Layout(gtx)
-											}),
-											layout.Rigid(func(gtx page.C) page.D {
-												return p.pluginTLSServerName.Layout(gtx, th, "")
-											}),
-										)
-									})
-								}),
-							)
+							return p.pluginTLS.Layout(gtx, th)
 						}),
 					)
 				}),
@@ -689,12 +645,7 @@ func (p *autherPage) generateConfig() *api.AutherConfig {
 			Type: p.pluginType.Value(),
 			Addr: p.pluginAddr.Text(),
 		}
-		if p.pluginEnableTLS.Value() {
-			cfg.Plugin.TLS = &api.TLSConfig{
-				Secure:     p.pluginTLSSecure.Value(),
-				ServerName: strings.TrimSpace(p.pluginTLSServerName.Text()),
-			}
-		}
+		cfg.Plugin.TLS = p.pluginTLS.Config()
 		return cfg
 	}
 
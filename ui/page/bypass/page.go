@@ -59,11 +59,9 @@ type bypassPage struct {
 	httpURL              component.TextField
 	httpTimeout          component.TextField
 
-	pluginType          ui_widget.Selector
-	pluginAddr          component.TextField
-	pluginEnableTLS     ui_widget.Switcher
-	pluginTLSSecure     ui_widget.Switcher
-	pluginTLSServerName component.TextField
+	pluginType ui_widget.Selector
+	pluginAddr component.TextField
+	pluginTLS  *page.TLSEditor
 
 	id   string
 	perm page.Perm
@@ -162,14 +160,7 @@ func NewPage(r *page.Router) page.Page {
 				MaxLen:     128,
 			},
 		},
-		pluginEnableTLS: ui_widget.Switcher{Title: i18n.TLS},
-		pluginTLSSecure: ui_widget.Switcher{Title: i18n.VerifyServerCert},
-		pluginTLSServerName: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
+		pluginTLS: page.NewTLSEditor(r),
 
 		delDialog: ui_widget.Dialog{Title: i18n.DeleteBypass},
 	}
@@ -179,7 +170,7 @@ func NewPage(r *page.Router) page.Page {
 
 func (p *bypassPage) Init(opts ...page.PageOption) {
 	if server := config.CurrentServer(); server != nil {
-		p.readonly = server.Readonly
+		p.readonly = server.IsReadonly()
 	}
 
 	var options page.PageOptions
@@ -206,7 +197,7 @@ func (p *bypassPage) Init(opts ...page.PageOption) {
 		cfg := api.GetConfig()
 		for _, v := range cfg.Bypasses {
 			if v.Name == p.id {
-				bypass = v
+				bypass = v.Copy()
 				break
 			}
 		}
@@ -221,7 +212,7 @@ func (p *bypassPage) Init(opts ...page.PageOption) {
 	}
 
 	p.name.SetText(bypass.Name)
-	p.whitelist.SetValue(bypass.Whitelist)
+	p.whitelist.SetValue(bypass.Whitelist || bypass.Reverse)
 	p.matchers = bypass.Matchers
 	p.matcherSelector.Clear()
 	p.matcherSelector.Select(ui_widget.SelectorItem{Value: strconv.Itoa(len(p.matchers))})
@@ -255,9 +246,7 @@ func (p *bypassPage) Init(opts ...page.PageOption) {
 	{
 		p.pluginType.Clear()
 		p.pluginAddr.Clear()
-		p.pluginEnableTLS.SetValue(false)
-		p.pluginTLSSecure.SetValue(false)
-		p.pluginTLSServerName.Clear()
+		p.pluginTLS.Init(nil)
 
 		if bypass.Plugin != nil {
 			p.mode.Value = string(page.PluginMode)
@@ -268,12 +257,7 @@ func (p *bypassPage) Init(opts ...page.PageOption) {
 				}
 			}
 			p.pluginAddr.SetText(bypass.Plugin.Addr)
-
-			if bypass.Plugin.TLS != nil {
-				p.pluginEnableTLS.SetValue(true)
-				p.pluginTLSSecure.SetValue(bypass.Plugin.TLS.Secure)
-				p.pluginTLSServerName.SetText(bypass.Plugin.TLS.ServerName)
-			}
+			p.pluginTLS.Init(bypass.Plugin.TLS)
 		}
 	}
 }
@@ -449,35 +433,7 @@ func (p *bypassPage) layout(gtx page.C, th *page.T) page.D {
 							return p.pluginType.Layout(gtx, th)
 						}),
 						layout.Rigid(func(gtx page.C) page.D {
-							return p.pluginEnableTLS.Layout(gtx, th)
-						}),
-						layout.Rigid(func(gtx page.C) page.D {
-							if !p.pluginEnableTLS.Value() {
-								return page.D{}
-							}
-
-							return layout.Flex{
-								Axis: layout.Vertical,
-							}.Layout(gtx,
-								layout.Rigid(func(gtx page.C) page.D {
-									return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
-										return layout.Flex{
-											Axis: layout.Vertical,
-										}.Layout(gtx,
-											layout.Rigid(func(gtx page.C) page.D {
-												return p.pluginTLSSecure.Layout(gtx, th)
-											}),
-
-											layout.Rigid(func(gtx page.C) page.D {
-												return material.Body1(th, i18n.ServerName.Value()).Layout(gtx)
-											}),
-											layout.Rigid(func(gtx page.C) page.D {
-												return p.pluginTLSServerName.Layout(gtx, th, "")
-											}),
-										)
-									})
-								}),
-							)
+							return p.pluginTLS.Layout(gtx, th)
 						}),
 					)
 				}),
@@ -701,12 +657,7 @@ func (p *bypassPage) generateConfig() *api.BypassConfig {
 			Type: p.pluginType.Value(),
 			Addr: p.pluginAddr.Text(),
 		}
-		if p.pluginEnableTLS.Value() {
-			cfg.Plugin.TLS = &api.TLSConfig{
-				Secure:     p.pluginTLSSecure.Value(),
-				ServerName: strings.TrimSpace(p.pluginTLSServerName.Text()),
-			}
-		}
+		cfg.Plugin.TLS = p.pluginTLS.Config()
 		return cfg
 	}
 
@@ -85,7 +85,7 @@ func NewPage(r *page.Router) page.Page {
 
 func (p *chainPage) Init(opts ...page.PageOption) {
 	if server := config.CurrentServer(); server != nil {
-		p.readonly = server.Readonly
+		p.readonly = server.IsReadonly()
 	}
 
 	var options page.PageOptions
@@ -110,7 +110,7 @@ func (p *chainPage) Init(opts ...page.PageOption) {
 	chain := &api.ChainConfig{}
 	for _, ch := range cfg.Chains {
 		if ch.Name == p.id {
-			chain = ch
+			chain = ch.Copy()
 			break
 		}
 	}
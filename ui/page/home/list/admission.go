@@ -28,7 +28,7 @@ func Admission(r *page.Router) List {
 
 func (p *admissionList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	admissions := cfg.Admissions
+	admissions := withCreated(cfg.Admissions, "admission", func(v *api.AdmissionConfig) string { return v.Name })
 
 	if len(admissions) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *admissionList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(admissions), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageAdmission,
-				ID:   admissions[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Admissions) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageAdmission,
+					ID:   admissions[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		admission := admissions[index]
@@ -73,6 +80,9 @@ func (p *admissionList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "admission", admission.Name)
+								}),
 							)
 						}),
 					)
@@ -28,7 +28,7 @@ func Auther(r *page.Router) List {
 
 func (p *autherList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	authers := cfg.Authers
+	authers := withCreated(cfg.Authers, "auther", func(v *api.AutherConfig) string { return v.Name })
 
 	if len(authers) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *autherList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(authers), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageAuther,
-				ID:   authers[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Authers) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageAuther,
+					ID:   authers[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		auther := authers[index]
@@ -73,6 +80,9 @@ func (p *autherList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "auther", auther.Name)
+								}),
 							)
 						}),
 					)
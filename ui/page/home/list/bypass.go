@@ -28,7 +28,7 @@ func Bypass(r *page.Router) List {
 
 func (p *bypassList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	bypasses := cfg.Bypasses
+	bypasses := withCreated(cfg.Bypasses, "bypass", func(v *api.BypassConfig) string { return v.Name })
 
 	if len(bypasses) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *bypassList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(bypasses), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageBypass,
-				ID:   bypasses[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Bypasses) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageBypass,
+					ID:   bypasses[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		bypass := bypasses[index]
@@ -73,6 +80,9 @@ func (p *bypassList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "bypass", bypass.Name)
+								}),
 							)
 						}),
 					)
@@ -30,7 +30,7 @@ func Chain(r *page.Router) List {
 
 func (p *chainList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	chains := cfg.Chains
+	chains := withCreated(cfg.Chains, "chain", func(v *api.ChainConfig) string { return v.Name })
 
 	if len(chains) > len(p.states) {
 		states := p.states
@@ -40,11 +40,18 @@ func (p *chainList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(chains), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageChain,
-				ID:   chains[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Chains) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageChain,
+					ID:   chains[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		chain := chains[index]
@@ -75,6 +82,9 @@ func (p *chainList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "chain", chain.Name)
+								}),
 							)
 						}),
 						layout.Rigid(layout.Spacer{Height: 4}.Layout),
@@ -30,7 +30,7 @@ func Hop(r *page.Router) List {
 
 func (p *hopList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	hops := cfg.Hops
+	hops := withCreated(cfg.Hops, "hop", func(v *api.HopConfig) string { return v.Name })
 
 	if len(hops) > len(p.states) {
 		states := p.states
@@ -40,11 +40,18 @@ func (p *hopList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(hops), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageHop,
-				ID:   hops[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Hops) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageHop,
+					ID:   hops[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		hop := hops[index]
@@ -75,6 +82,9 @@ func (p *hopList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "hop", hop.Name)
+								}),
 							)
 						}),
 						layout.Rigid(layout.Spacer{Height: 4}.Layout),
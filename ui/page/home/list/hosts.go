@@ -28,7 +28,7 @@ func HostMapper(r *page.Router) List {
 
 func (p *hostMapperList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	mappers := cfg.Hosts
+	mappers := withCreated(cfg.Hosts, "hosts", func(v *api.HostsConfig) string { return v.Name })
 
 	if len(mappers) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *hostMapperList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(mappers), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageHosts,
-				ID:   mappers[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Hosts) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageHosts,
+					ID:   mappers[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		mapper := mappers[index]
@@ -73,6 +80,9 @@ func (p *hostMapperList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "hosts", mapper.Name)
+								}),
 							)
 						}),
 					)
@@ -28,7 +28,7 @@ func Limiter(r *page.Router) List {
 
 func (p *limiterList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	limiters := cfg.Limiters
+	limiters := withCreated(cfg.Limiters, "limiter", func(v *api.LimiterConfig) string { return v.Name })
 
 	if len(limiters) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *limiterList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(limiters), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageLimiter,
-				ID:   limiters[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Limiters) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageLimiter,
+					ID:   limiters[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		limiter := limiters[index]
@@ -73,6 +80,9 @@ func (p *limiterList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "limiter", limiter.Name)
+								}),
 							)
 						}),
 					)
@@ -1,8 +1,19 @@
 package list
 
 import (
+	"encoding/json"
+	"image/color"
+	"slices"
+
+	"gioui.org/layout"
 	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api/queue"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
 	"github.com/go-gost/gostctl/ui/page"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
 )
 
 type List interface {
@@ -12,3 +23,54 @@ type List interface {
 type state struct {
 	clk widget.Clickable
 }
+
+// layoutPending marks the object which has changes queued for the current server.
+func layoutPending(gtx page.C, th *page.T, kind string, name string) page.D {
+	server := config.CurrentServer()
+	if server == nil || !queue.Pending(server.Name, kind, name) {
+		return page.D{}
+	}
+
+	return layout.Inset{Left: 8}.Layout(gtx, func(gtx page.C) page.D {
+		return component.SurfaceStyle{
+			Theme:       th,
+			ShadowStyle: component.ShadowStyle{CornerRadius: 12},
+			Fill:        color.NRGBA(colornames.Orange500),
+		}.Layout(gtx, func(gtx page.C) page.D {
+			return layout.Inset{
+				Top:    2,
+				Bottom: 2,
+				Left:   8,
+				Right:  8,
+			}.Layout(gtx, func(gtx page.C) page.D {
+				label := material.Caption(th, i18n.Pending.Value())
+				label.Color = color.NRGBA(colornames.White)
+				return label.Layout(gtx)
+			})
+		})
+	})
+}
+
+// withCreated appends the objects whose creation is queued for the current server to objects,
+// they are listed as pending until the queue is replayed.
+func withCreated[T any](objects []*T, kind string, name func(*T) string) []*T {
+	server := config.CurrentServer()
+	if server == nil {
+		return objects
+	}
+
+	for _, c := range queue.List(server.Name) {
+		if c.Kind != kind || c.Op != queue.OpCreate {
+			continue
+		}
+		if slices.ContainsFunc(objects, func(v *T) bool { return name(v) == c.Name }) {
+			continue
+		}
+		v := new(T)
+		if err := json.Unmarshal([]byte(c.Value), v); err != nil {
+			continue
+		}
+		objects = append(objects, v)
+	}
+	return objects
+}
@@ -28,7 +28,7 @@ func Observer(r *page.Router) List {
 
 func (p *observerList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	observers := cfg.Observers
+	observers := withCreated(cfg.Observers, "observer", func(v *api.ObserverConfig) string { return v.Name })
 
 	if len(observers) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *observerList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(observers), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageObserver,
-				ID:   observers[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Observers) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageObserver,
+					ID:   observers[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		observer := observers[index]
@@ -73,6 +80,9 @@ func (p *observerList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "observer", observer.Name)
+								}),
 							)
 						}),
 					)
@@ -28,7 +28,7 @@ func Recorder(r *page.Router) List {
 
 func (p *recorderList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	recorders := cfg.Recorders
+	recorders := withCreated(cfg.Recorders, "recorder", func(v *api.RecorderConfig) string { return v.Name })
 
 	if len(recorders) > len(p.states) {
 		states := p.states
@@ -38,11 +38,18 @@ func (p *recorderList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(recorders), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageRecorder,
-				ID:   recorders[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Recorders) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageRecorder,
+					ID:   recorders[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		recorder := recorders[index]
@@ -73,6 +80,9 @@ func (p *recorderList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "recorder", recorder.Name)
+								}),
 							)
 						}),
 					)
@@ -30,7 +30,7 @@ func Resolver(r *page.Router) List {
 
 func (p *resolverList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	resolvers := cfg.Resolvers
+	resolvers := withCreated(cfg.Resolvers, "resolver", func(v *api.ResolverConfig) string { return v.Name })
 
 	if len(resolvers) > len(p.states) {
 		states := p.states
@@ -40,11 +40,18 @@ func (p *resolverList) Layout(gtx page.C, th *page.T) page.D {
 
 	return p.list.Layout(gtx, len(resolvers), func(gtx page.C, index int) page.D {
 		if p.states[index].clk.Clicked(gtx) {
-			p.router.Goto(page.Route{
-				Path: page.PageResolver,
-				ID:   resolvers[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Resolvers) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				p.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				p.router.Goto(page.Route{
+					Path: page.PageResolver,
+					ID:   resolvers[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		resolver := resolvers[index]
@@ -75,6 +82,9 @@ func (p *resolverList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "resolver", resolver.Name)
+								}),
 							)
 						}),
 						layout.Rigid(layout.Spacer{Height: 4}.Layout),
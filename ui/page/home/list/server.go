@@ -2,21 +2,42 @@ package list
 
 import (
 	"image/color"
+	"slices"
+	"strings"
 
 	"gioui.org/font"
 	"gioui.org/layout"
+	"gioui.org/widget"
 	"gioui.org/widget/material"
+	"gioui.org/x/component"
 	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
 	"github.com/go-gost/gostctl/ui/icons"
 	"github.com/go-gost/gostctl/ui/page"
 	"github.com/go-gost/gostctl/ui/theme"
 	"golang.org/x/exp/shiny/materialdesign/colornames"
 )
 
+type groupState struct {
+	clk       widget.Clickable
+	collapsed bool
+}
+
+// serverItem is a row of the server list, either the header of a group or a server.
+type serverItem struct {
+	group  string
+	server *config.Server
+	// index is the index of the server in the config.
+	index int
+}
+
 type serverList struct {
 	router *page.Router
 	list   layout.List
-	states []state
+	search component.TextField
+	// states are the states of the servers by name, as the list is filtered.
+	states map[string]*state
+	groups map[string]*groupState
 }
 
 func Server(r *page.Router) List {
@@ -25,98 +46,250 @@ func Server(r *page.Router) List {
 		list: layout.List{
 			Axis: layout.Vertical,
 		},
-		states: make([]state, 16),
+		search: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     128,
+			},
+		},
+		states: make(map[string]*state),
+		groups: make(map[string]*groupState),
 	}
 }
 
 func (l *serverList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := config.Get()
-	servers := cfg.Servers
-	if len(servers) > len(l.states) {
-		states := l.states
-		l.states = make([]state, len(servers))
-		copy(l.states, states)
-	}
+	items := l.items(cfg.Servers, strings.TrimSpace(l.search.Text()))
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			if len(cfg.Servers) == 0 {
+				return page.D{}
+			}
 
-	return l.list.Layout(gtx, len(servers), func(gtx page.C, index int) page.D {
-		if l.states[index].clk.Clicked(gtx) {
-			l.router.Goto(page.Route{
-				Path: page.PageServer,
-				ID:   servers[index].Name,
-				Perm: page.PermReadWriteDelete,
+			return layout.Inset{
+				Left:  8,
+				Right: 8,
+			}.Layout(gtx, func(gtx page.C) page.D {
+				l.search.Prefix = func(gtx page.C) page.D {
+					gtx.Constraints.Min.X = gtx.Dp(20)
+					return icons.IconSearch.Layout(gtx, th.Fg)
+				}
+				return l.search.Layout(gtx, th, i18n.SearchServer.Value())
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			if len(items) == 0 && len(cfg.Servers) > 0 {
+				return layout.UniformInset(16).Layout(gtx, material.Body1(th, i18n.ServerNone.Value()).Layout)
+			}
+
+			return l.list.Layout(gtx, len(items), func(gtx page.C, index int) page.D {
+				item := items[index]
+				if item.server == nil {
+					return l.layoutGroup(gtx, th, item.group)
+				}
+				return l.layoutServer(gtx, th, item.server, item.index == cfg.CurrentServer)
 			})
+		}),
+	)
+}
+
+// items returns the rows of the servers matching the query, grouped by the group of the servers.
+// The servers are not grouped if none of them has a group.
+func (l *serverList) items(servers []*config.Server, query string) []serverItem {
+	var groups []string
+	grouped := map[string][]serverItem{}
+	for i, server := range servers {
+		if !server.Match(query) {
+			continue
+		}
+		if _, ok := grouped[server.Group]; !ok {
+			groups = append(groups, server.Group)
 		}
+		grouped[server.Group] = append(grouped[server.Group], serverItem{server: server, index: i})
+	}
+
+	if len(groups) == 1 && groups[0] == "" {
+		return grouped[""]
+	}
+
+	// the ungrouped servers come last.
+	slices.SortFunc(groups, func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case a == "":
+			return 1
+		case b == "":
+			return -1
+		default:
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		}
+	})
+
+	var items []serverItem
+	for _, group := range groups {
+		items = append(items, serverItem{group: group})
+		// the matched servers are shown even in the collapsed groups when searching.
+		if gs := l.groups[group]; gs != nil && gs.collapsed && query == "" {
+			continue
+		}
+		items = append(items, grouped[group]...)
+	}
+	return items
+}
+
+func (l *serverList) layoutGroup(gtx page.C, th *page.T, group string) page.D {
+	gs := l.groups[group]
+	if gs == nil {
+		gs = &groupState{}
+		l.groups[group] = gs
+	}
+	if gs.clk.Clicked(gtx) {
+		gs.collapsed = !gs.collapsed
+	}
 
-		return layout.Inset{
-			Top:    8,
-			Bottom: 8,
-			Left:   8,
-			Right:  8,
+	name := group
+	if name == "" {
+		name = i18n.Ungrouped.Value()
+	}
+
+	return layout.Inset{
+		Top:   8,
+		Left:  8,
+		Right: 8,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return material.Clickable(gtx, &gs.clk, func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						gtx.Constraints.Min.X = gtx.Dp(20)
+						if gs.collapsed {
+							return icons.IconNavRight.Layout(gtx, th.Fg)
+						}
+						return icons.IconNavExpandMore.Layout(gtx, th.Fg)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Flexed(1, func(gtx page.C) page.D {
+						label := material.Body1(th, name)
+						label.Font.Weight = font.SemiBold
+						return label.Layout(gtx)
+					}),
+				)
+			})
+		})
+	})
+}
+
+func (l *serverList) layoutServer(gtx page.C, th *page.T, server *config.Server, current bool) page.D {
+	st := l.states[server.Name]
+	if st == nil {
+		st = &state{}
+		l.states[server.Name] = st
+	}
+
+	if st.clk.Clicked(gtx) {
+		l.router.Goto(page.Route{
+			Path: page.PageServer,
+			ID:   server.Name,
+			Perm: page.PermReadWriteDelete,
+		})
+	}
+
+	return layout.Inset{
+		Top:    8,
+		Bottom: 8,
+		Left:   8,
+		Right:  8,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return material.ButtonLayoutStyle{
+			Background:   theme.Current().ListBg,
+			CornerRadius: 12,
+			Button:       &st.clk,
 		}.Layout(gtx, func(gtx page.C) page.D {
-			return material.ButtonLayoutStyle{
-				Background:   theme.Current().ListBg,
-				CornerRadius: 12,
-				Button:       &l.states[index].clk,
-			}.Layout(gtx, func(gtx page.C) page.D {
-				return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
-					return layout.Flex{
-						Alignment: layout.Middle,
-						Spacing:   layout.SpaceBetween,
-					}.Layout(gtx,
-						layout.Flexed(1, func(gtx page.C) page.D {
-							return layout.Flex{
-								Axis: layout.Vertical,
-							}.Layout(gtx,
-								layout.Rigid(func(gtx page.C) page.D {
-									label := material.Body1(th, servers[index].Name)
-									label.Font.Weight = font.SemiBold
-									return label.Layout(gtx)
-								}),
-								layout.Rigid(layout.Spacer{Height: 4}.Layout),
-								layout.Rigid(material.Body2(th, servers[index].URL).Layout),
-								layout.Rigid(layout.Spacer{Height: 4}.Layout),
-								layout.Rigid(func(gtx page.C) page.D {
-									return layout.Flex{
-										Spacing:   layout.SpaceBetween,
-										Alignment: layout.Middle,
-									}.Layout(gtx,
-										layout.Rigid(func(gtx page.C) page.D {
-											gtx.Constraints.Min.X = gtx.Dp(16)
-											return icons.IconActionUpdate.Layout(gtx, th.Fg)
-										}),
-										layout.Rigid(layout.Spacer{Width: 4}.Layout),
-										layout.Flexed(1, material.Body2(th, servers[index].Interval.String()).Layout),
-									)
-								}),
-								layout.Rigid(layout.Spacer{Height: 4}.Layout),
-								layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Alignment: layout.Middle,
+					Spacing:   layout.SpaceBetween,
+				}.Layout(gtx,
+					layout.Flexed(1, func(gtx page.C) page.D {
+						return layout.Flex{
+							Axis: layout.Vertical,
+						}.Layout(gtx,
+							layout.Rigid(func(gtx page.C) page.D {
+								label := material.Body1(th, server.Name)
+								label.Font.Weight = font.SemiBold
+								return label.Layout(gtx)
+							}),
+							layout.Rigid(layout.Spacer{Height: 4}.Layout),
+							layout.Rigid(material.Body2(th, server.URL).Layout),
+							layout.Rigid(layout.Spacer{Height: 4}.Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return layout.Flex{
+									Spacing:   layout.SpaceBetween,
+									Alignment: layout.Middle,
+								}.Layout(gtx,
+									layout.Rigid(func(gtx page.C) page.D {
+										gtx.Constraints.Min.X = gtx.Dp(16)
+										return icons.IconActionUpdate.Layout(gtx, th.Fg)
+									}),
+									layout.Rigid(layout.Spacer{Width: 4}.Layout),
+									layout.Flexed(1, material.Body2(th, server.Interval.String()).Layout),
+								)
+							}),
+							layout.Rigid(layout.Spacer{Height: 4}.Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return layout.Flex{
+									Spacing:   layout.SpaceBetween,
+									Alignment: layout.Middle,
+								}.Layout(gtx,
+									layout.Rigid(func(gtx page.C) page.D {
+										gtx.Constraints.Min.X = gtx.Dp(16)
+										return icons.IconActionHourGlassEmpty.Layout(gtx, th.Fg)
+									}),
+									layout.Rigid(layout.Spacer{Width: 4}.Layout),
+									layout.Flexed(1, material.Body2(th, server.Timeout.String()).Layout),
+								)
+							}),
+							layout.Rigid(func(gtx page.C) page.D {
+								if len(server.Tags) == 0 {
+									return page.D{}
+								}
+
+								return layout.Inset{
+									Top: 4,
+								}.Layout(gtx, func(gtx page.C) page.D {
 									return layout.Flex{
 										Spacing:   layout.SpaceBetween,
 										Alignment: layout.Middle,
 									}.Layout(gtx,
 										layout.Rigid(func(gtx page.C) page.D {
 											gtx.Constraints.Min.X = gtx.Dp(16)
-											return icons.IconActionHourGlassEmpty.Layout(gtx, th.Fg)
+											return icons.IconLabel.Layout(gtx, th.Fg)
 										}),
 										layout.Rigid(layout.Spacer{Width: 4}.Layout),
-										layout.Flexed(1, material.Body2(th, servers[index].Timeout.String()).Layout),
+										layout.Flexed(1, material.Body2(th, strings.Join(server.Tags, ", ")).Layout),
 									)
-								}),
-							)
-						}),
-						layout.Rigid(layout.Spacer{Width: 4}.Layout),
-						layout.Rigid(func(gtx page.C) page.D {
-							if index == cfg.CurrentServer {
-								gtx.Constraints.Min.X = gtx.Dp(12)
-								if state := servers[index].State(); state == config.ServerError {
-									return icons.IconCircle.Layout(gtx, color.NRGBA(colornames.Red500))
-								}
-								return icons.IconCircle.Layout(gtx, color.NRGBA(colornames.Green500))
+								})
+							}),
+						)
+					}),
+					layout.Rigid(layout.Spacer{Width: 4}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						if current {
+							gtx.Constraints.Min.X = gtx.Dp(12)
+							if state := server.State(); state == config.ServerError {
+								return icons.IconCircle.Layout(gtx, color.NRGBA(colornames.Red500))
 							}
-							return page.D{}
-						}),
-					)
-				})
+							return icons.IconCircle.Layout(gtx, color.NRGBA(colornames.Green500))
+						}
+						return page.D{}
+					}),
+				)
 			})
 		})
 	})
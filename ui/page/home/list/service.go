@@ -38,7 +38,7 @@ func Service(r *page.Router) List {
 
 func (l *serviceList) Layout(gtx page.C, th *page.T) page.D {
 	cfg := api.GetConfig()
-	services := cfg.Services
+	services := withCreated(cfg.Services, "service", func(v *api.ServiceConfig) string { return v.Name })
 
 	if len(services) > len(l.states) {
 		states := l.states
@@ -48,11 +48,18 @@ func (l *serviceList) Layout(gtx page.C, th *page.T) page.D {
 
 	return l.list.Layout(gtx, len(services), func(gtx page.C, index int) page.D {
 		if l.states[index].clk.Clicked(gtx) {
-			l.router.Goto(page.Route{
-				Path: page.PageService,
-				ID:   services[index].Name,
-				Perm: page.PermReadWriteDelete,
-			})
+			if index >= len(cfg.Services) {
+				// the object is not created on the server yet, its queued change is on the pending page.
+				l.router.Goto(page.Route{
+					Path: page.PagePending,
+				})
+			} else {
+				l.router.Goto(page.Route{
+					Path: page.PageService,
+					ID:   services[index].Name,
+					Perm: page.PermReadWriteDelete,
+				})
+			}
 		}
 
 		service := services[index]
@@ -121,6 +128,9 @@ func (l *serviceList) Layout(gtx page.C, th *page.T) page.D {
 									label.Font.Weight = font.SemiBold
 									return label.Layout(gtx)
 								}),
+								layout.Rigid(func(gtx page.C) page.D {
+									return layoutPending(gtx, th, "service", service.Name)
+								}),
 								layout.Rigid(layout.Spacer{Width: 4}.Layout),
 								layout.Rigid(func(gtx page.C) page.D {
 									gtx.Constraints.Min.X = gtx.Dp(10)
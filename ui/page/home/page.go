@@ -24,6 +24,7 @@ type homePage struct {
 	pages       []navPage
 	btnAdd      widget.Clickable
 	btnSettings widget.Clickable
+	btnActivity widget.Clickable
 }
 
 func NewPage(r *page.Router) page.Page {
@@ -98,7 +99,7 @@ func NewPage(r *page.Router) page.Page {
 
 func (p *homePage) Init(opts ...page.PageOption) {
 	if server := config.CurrentServer(); server != nil {
-		p.readonly = server.Readonly
+		p.readonly = server.IsReadonly()
 	}
 }
 
@@ -139,6 +140,19 @@ func (p *homePage) Layout(gtx page.C) page.D {
 								return icons.IconApp.Layout(gtx)
 							}),
 							layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								if p.btnActivity.Clicked(gtx) {
+									p.router.Goto(page.Route{
+										Path: page.PageActivity,
+									})
+								}
+
+								btn := material.IconButton(th, &p.btnActivity, icons.IconHistory, "Activity")
+								btn.Color = th.Fg
+								btn.Background = th.Bg
+								return btn.Layout(gtx)
+							}),
+							layout.Rigid(layout.Spacer{Width: 4}.Layout),
 							layout.Rigid(func(gtx page.C) page.D {
 								if p.btnSettings.Clicked(gtx) {
 									p.router.Goto(page.Route{
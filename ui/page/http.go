@@ -0,0 +1,443 @@
+package page
+
+import (
+	"fmt"
+	"image/color"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+type httpKV struct {
+	k      string
+	v      string
+	clk    widget.Clickable
+	delete widget.Clickable
+
+	// re and reErr are the compiled pattern k of a rewrite rule, reSrc is the pattern they are compiled from.
+	re    *regexp.Regexp
+	reErr error
+	reSrc string
+}
+
+// regexp returns the compiled pattern of the rewrite rule, it is compiled again only if the pattern is changed.
+func (kv *httpKV) regexp() (*regexp.Regexp, error) {
+	if (kv.re == nil && kv.reErr == nil) || kv.reSrc != kv.k {
+		kv.re, kv.reErr = regexp.Compile(kv.k)
+		kv.reSrc = kv.k
+	}
+	return kv.re, kv.reErr
+}
+
+// HTTPNodeEditor edits an api.HTTPNodeConfig of a forwarder node or chain node.
+type HTTPNodeEditor struct {
+	router *Router
+
+	enable   ui_widget.Switcher
+	host     component.TextField
+	username component.TextField
+	password component.TextField
+
+	headers        []*httpKV
+	headerSelector ui_widget.Selector
+	headerAdd      widget.Clickable
+	headerDialog   ui_widget.MetadataDialog
+
+	rewrites        []*httpKV
+	rewriteSelector ui_widget.Selector
+	rewriteAdd      widget.Clickable
+	rewriteDialog   ui_widget.MetadataDialog
+	testURL         component.TextField
+
+	delHeaderDialog  ui_widget.Dialog
+	delRewriteDialog ui_widget.Dialog
+}
+
+func NewHTTPNodeEditor(r *Router) *HTTPNodeEditor {
+	return &HTTPNodeEditor{
+		router: r,
+		enable: ui_widget.Switcher{Title: i18n.HTTP},
+		host: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		username: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		password: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		headerSelector: ui_widget.Selector{Title: i18n.HTTPHeaders},
+		headerDialog: ui_widget.MetadataDialog{
+			Title:      i18n.HTTPHeader,
+			KeyTitle:   i18n.Name,
+			ValueTitle: i18n.MetadataValue,
+			K: component.TextField{
+				Editor: widget.Editor{
+					SingleLine: true,
+					MaxLen:     255,
+				},
+			},
+			V: component.TextField{
+				Editor: widget.Editor{
+					SingleLine: true,
+					MaxLen:     1024,
+				},
+			},
+		},
+		rewriteSelector: ui_widget.Selector{Title: i18n.HTTPURLRewrite},
+		rewriteDialog: ui_widget.MetadataDialog{
+			Title:      i18n.HTTPURLRewrite,
+			KeyTitle:   i18n.RewriteMatch,
+			ValueTitle: i18n.RewriteReplacement,
+			K: component.TextField{
+				Editor: widget.Editor{
+					SingleLine: true,
+					MaxLen:     1024,
+				},
+			},
+			V: component.TextField{
+				Editor: widget.Editor{
+					SingleLine: true,
+					MaxLen:     1024,
+				},
+			},
+		},
+		testURL: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     1024,
+			},
+		},
+		delHeaderDialog:  ui_widget.Dialog{Title: i18n.DeleteHeader},
+		delRewriteDialog: ui_widget.Dialog{Title: i18n.DeleteRewrite},
+	}
+}
+
+func (p *HTTPNodeEditor) Init(cfg *api.HTTPNodeConfig) {
+	p.enable.SetValue(false)
+	p.host.Clear()
+	p.username.Clear()
+	p.password.Clear()
+	p.headers = nil
+	p.rewrites = nil
+	p.testURL.Clear()
+
+	if cfg != nil {
+		p.enable.SetValue(true)
+		p.host.SetText(cfg.Host)
+		if cfg.Auth != nil {
+			p.username.SetText(cfg.Auth.Username)
+			p.password.SetText(cfg.Auth.Password)
+		}
+
+		var keys []string
+		for k := range cfg.Header {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p.headers = append(p.headers, &httpKV{k: k, v: cfg.Header[k]})
+		}
+
+		for _, rewrite := range cfg.Rewrite {
+			p.rewrites = append(p.rewrites, &httpKV{k: rewrite.Match, v: rewrite.Replacement})
+		}
+	}
+
+	p.updateSelectors()
+}
+
+func (p *HTTPNodeEditor) updateSelectors() {
+	p.headerSelector.Clear()
+	p.headerSelector.Select(ui_widget.SelectorItem{Value: strconv.Itoa(len(p.headers))})
+	p.rewriteSelector.Clear()
+	p.rewriteSelector.Select(ui_widget.SelectorItem{Value: strconv.Itoa(len(p.rewrites))})
+}
+
+// Config returns the edited HTTP settings, or nil if they are disabled.
+func (p *HTTPNodeEditor) Config() *api.HTTPNodeConfig {
+	if !p.enable.Value() {
+		return nil
+	}
+
+	cfg := &api.HTTPNodeConfig{
+		Host: strings.TrimSpace(p.host.Text()),
+	}
+	if username := strings.TrimSpace(p.username.Text()); username != "" {
+		cfg.Auth = &api.AuthConfig{
+			Username: username,
+			Password: strings.TrimSpace(p.password.Text()),
+		}
+	}
+	if len(p.headers) > 0 {
+		cfg.Header = make(map[string]string)
+		for _, header := range p.headers {
+			cfg.Header[header.k] = header.v
+		}
+	}
+	for _, rewrite := range p.rewrites {
+		cfg.Rewrite = append(cfg.Rewrite, api.HTTPURLRewriteConfig{
+			Match:       rewrite.k,
+			Replacement: rewrite.v,
+		})
+	}
+
+	return cfg
+}
+
+func (p *HTTPNodeEditor) Layout(gtx C, th *T) D {
+	if p.headerAdd.Clicked(gtx) {
+		p.showDialog(gtx, &p.headerDialog, &p.headers, -1)
+	}
+	if p.rewriteAdd.Clicked(gtx) {
+		p.showDialog(gtx, &p.rewriteDialog, &p.rewrites, -1)
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return p.enable.Layout(gtx, th)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !p.enable.Value() {
+				return D{}
+			}
+
+			return layout.UniformInset(8).Layout(gtx, func(gtx C) D {
+				return layout.Flex{
+					Axis: layout.Vertical,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return p.host.Layout(gtx, th, i18n.RewriteHostHeader.Value())
+					}),
+					layout.Rigid(func(gtx C) D {
+						return p.username.Layout(gtx, th, i18n.Username.Value())
+					}),
+					layout.Rigid(func(gtx C) D {
+						return p.password.Layout(gtx, th, i18n.Password.Value())
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+					layout.Rigid(func(gtx C) D {
+						return p.layoutHeader(gtx, th, &p.headerSelector, &p.headerAdd)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return p.layoutList(gtx, th, &p.headerDialog, &p.delHeaderDialog, &p.headers)
+					}),
+
+					layout.Rigid(func(gtx C) D {
+						return p.layoutHeader(gtx, th, &p.rewriteSelector, &p.rewriteAdd)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return p.layoutList(gtx, th, &p.rewriteDialog, &p.delRewriteDialog, &p.rewrites)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if len(p.rewrites) == 0 {
+							return D{}
+						}
+						return p.layoutRewriteTest(gtx, th)
+					}),
+				)
+			})
+		}),
+	)
+}
+
+func (p *HTTPNodeEditor) layoutHeader(gtx C, th *T, selector *ui_widget.Selector, add *widget.Clickable) D {
+	return layout.Flex{
+		Alignment: layout.Middle,
+	}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			return selector.Layout(gtx, th)
+		}),
+		layout.Rigid(layout.Spacer{Width: 8}.Layout),
+		layout.Rigid(func(gtx C) D {
+			btn := material.IconButton(th, add, icons.IconAdd, "Add")
+			btn.Background = theme.Current().ContentSurfaceBg
+			btn.Color = th.Fg
+			return btn.Layout(gtx)
+		}),
+	)
+}
+
+func (p *HTTPNodeEditor) layoutList(gtx C, th *T, dialog *ui_widget.MetadataDialog, delDialog *ui_widget.Dialog, items *[]*httpKV) D {
+	for i, item := range *items {
+		if item.clk.Clicked(gtx) {
+			p.showDialog(gtx, dialog, items, i)
+			break
+		}
+
+		if item.delete.Clicked(gtx) {
+			delDialog.OnClick = func(ok bool) {
+				p.router.HideModal(gtx)
+				if !ok {
+					return
+				}
+				*items = append((*items)[:i], (*items)[i+1:]...)
+				p.updateSelectors()
+			}
+			p.router.ShowModal(gtx, func(gtx C, th *T) D {
+				return delDialog.Layout(gtx, th)
+			})
+			break
+		}
+	}
+
+	var children []layout.FlexChild
+	for _, item := range *items {
+		children = append(children,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						return material.Clickable(gtx, &item.clk, func(gtx C) D {
+							return layout.UniformInset(8).Layout(gtx, func(gtx C) D {
+								return layout.Flex{
+									Axis: layout.Vertical,
+								}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										label := material.Body2(th, item.k)
+										label.Font.Weight = font.SemiBold
+										return label.Layout(gtx)
+									}),
+									layout.Rigid(layout.Spacer{Height: 4}.Layout),
+									layout.Rigid(material.Body2(th, item.v).Layout),
+								)
+							})
+						})
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx C) D {
+						btn := material.IconButton(th, &item.delete, icons.IconDelete, "delete")
+						btn.Background = theme.Current().ContentSurfaceBg
+						btn.Color = th.Fg
+						return btn.Layout(gtx)
+					}),
+				)
+			}),
+		)
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx, children...)
+}
+
+func (p *HTTPNodeEditor) layoutRewriteTest(gtx C, th *T) D {
+	result, rule, err := p.rewrite(strings.TrimSpace(p.testURL.Text()))
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(layout.Spacer{Height: 8}.Layout),
+		layout.Rigid(material.Body1(th, i18n.RewriteTest.Value()).Layout),
+		layout.Rigid(func(gtx C) D {
+			return p.testURL.Layout(gtx, th, i18n.RewriteTestHint.Value())
+		}),
+		layout.Rigid(func(gtx C) D {
+			if p.testURL.Text() == "" {
+				return D{}
+			}
+
+			var label material.LabelStyle
+			switch {
+			case err != nil:
+				label = material.Body2(th, err.Error())
+				label.Color = color.NRGBA(colornames.Red500)
+			case rule == 0:
+				label = material.Body2(th, i18n.RewriteNoMatch.Value())
+			default:
+				label = material.Body2(th, fmt.Sprintf("%s %d: %s", i18n.RewriteMatched.Value(), rule, result))
+			}
+			return layout.Inset{Top: 4}.Layout(gtx, label.Layout)
+		}),
+	)
+}
+
+// rewrite applies the rewrite rules to the path of rawURL the way gost does:
+// the first rule whose pattern matches the path is applied.
+// It returns the rewritten URL and the 1-based index of the matched rule.
+func (p *HTTPNodeEditor) rewrite(rawURL string) (string, int, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for i, rewrite := range p.rewrites {
+		re, err := rewrite.regexp()
+		if err != nil {
+			return "", 0, fmt.Errorf("%d: %w", i+1, err)
+		}
+		if re.MatchString(u.Path) {
+			if s := re.ReplaceAllString(u.Path, rewrite.v); s != "" {
+				u.Path = s
+				u.RawPath = ""
+				return u.String(), i + 1, nil
+			}
+		}
+	}
+
+	return "", 0, nil
+}
+
+func (p *HTTPNodeEditor) showDialog(gtx C, dialog *ui_widget.MetadataDialog, items *[]*httpKV, i int) {
+	dialog.K.Clear()
+	dialog.V.Clear()
+
+	if i >= 0 && i < len(*items) {
+		dialog.K.SetText((*items)[i].k)
+		dialog.V.SetText((*items)[i].v)
+	}
+
+	dialog.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		k, v := strings.TrimSpace(dialog.K.Text()), strings.TrimSpace(dialog.V.Text())
+		if k == "" {
+			return
+		}
+
+		if i >= 0 && i < len(*items) {
+			(*items)[i].k = k
+			(*items)[i].v = v
+		} else {
+			*items = append(*items, &httpKV{
+				k: k,
+				v: v,
+			})
+		}
+		p.updateSelectors()
+	}
+
+	p.router.ShowModal(gtx, func(gtx C, th *T) D {
+		return dialog.Layout(gtx, th)
+	})
+}
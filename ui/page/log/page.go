@@ -0,0 +1,340 @@
+package log
+
+import (
+	"bytes"
+	"image/color"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+// refreshInterval is the interval of showing the new records while the page is not paused.
+const refreshInterval = time.Second
+
+var levelOptions = []ui_widget.MenuOption{
+	{Name: "debug", Value: "debug"},
+	{Name: "info", Value: "info"},
+	{Name: "warn", Value: "warn"},
+	{Name: "error", Value: "error"},
+}
+
+func parseLevel(s string) slog.Level {
+	var level slog.Level
+	level.UnmarshalText([]byte(s))
+	return level
+}
+
+type logPage struct {
+	router *page.Router
+	menu   ui_widget.Menu
+	list   widget.List
+
+	btnBack  widget.Clickable
+	btnPause widget.Clickable
+	btnCopy  widget.Clickable
+
+	// level is the runtime level of the log, filter is the lowest level of the records shown.
+	level  ui_widget.Selector
+	filter ui_widget.Selector
+	search component.TextField
+
+	paused  bool
+	seq     uint64
+	records []config.LogRecord
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &logPage{
+		router: r,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
+		},
+		level:  ui_widget.Selector{Title: i18n.LogLevel},
+		filter: ui_widget.Selector{Title: i18n.Filter},
+		search: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     128,
+			},
+		},
+	}
+}
+
+func (p *logPage) Init(opts ...page.PageOption) {
+	p.paused = false
+	p.seq = 0
+	p.records = nil
+
+	level := strings.ToLower(config.LogLevel().String())
+	p.level.Clear()
+	p.filter.Clear()
+	for _, opt := range levelOptions {
+		if opt.Value == level {
+			p.level.Select(ui_widget.SelectorItem{Name: opt.Name, Value: opt.Value})
+		}
+		if opt.Value == "debug" {
+			p.filter.Select(ui_widget.SelectorItem{Name: opt.Name, Value: opt.Value})
+		}
+	}
+}
+
+func (p *logPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+	if p.btnPause.Clicked(gtx) {
+		p.paused = !p.paused
+	}
+
+	if !p.paused {
+		if seq := config.LogSeq(); seq != p.seq {
+			p.seq = seq
+			p.records = config.Logs()
+		}
+		gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(refreshInterval)})
+	}
+	records := p.filterRecords()
+
+	if p.btnCopy.Clicked(gtx) {
+		var b strings.Builder
+		// the copied records are in the order of time.
+		for i := len(records) - 1; i >= 0; i-- {
+			b.WriteString(records[i].String())
+			b.WriteString("\n")
+		}
+		gtx.Execute(clipboard.WriteCmd{
+			Data: io.NopCloser(bytes.NewBufferString(b.String())),
+		})
+		p.router.Notify(ui_widget.Message{
+			Type:    ui_widget.Success,
+			Content: i18n.LogsCopied.Value(),
+		})
+	}
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						title := material.H6(th, i18n.Logs.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Flexed(1, func(gtx page.C) page.D {
+						if !p.paused {
+							return page.D{}
+						}
+						return material.Body2(th, "("+i18n.Paused.Value()+")").Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						icon := icons.IconPause
+						if p.paused {
+							icon = icons.IconStart
+						}
+						btn := material.IconButton(th, &p.btnPause, icon, "Pause")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 4}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnCopy, icons.IconCopy, "Copy")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return p.layout(gtx, th, records)
+			})
+		}),
+	)
+}
+
+// filterRecords returns the records of the filter level and above, matching the search text.
+func (p *logPage) filterRecords() []config.LogRecord {
+	level := parseLevel(p.filter.Value())
+	search := strings.ToLower(strings.TrimSpace(p.search.Text()))
+
+	var records []config.LogRecord
+	for i := range p.records {
+		r := &p.records[i]
+		if r.Level < level {
+			continue
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(r.Msg), search) &&
+			!strings.Contains(strings.ToLower(r.Attrs), search) {
+			continue
+		}
+		records = append(records, *r)
+	}
+	return records
+}
+
+func (p *logPage) layout(gtx page.C, th *page.T, records []config.LogRecord) page.D {
+	if p.level.Clicked(gtx) {
+		p.showLevelMenu(gtx, i18n.LogLevel, &p.level, func(value string) {
+			config.SetLogLevel(parseLevel(value))
+		})
+	}
+	if p.filter.Clicked(gtx) {
+		p.showLevelMenu(gtx, i18n.Filter, &p.filter, nil)
+	}
+
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx,
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.level.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.filter.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					p.search.Prefix = func(gtx page.C) page.D {
+						gtx.Constraints.Min.X = gtx.Dp(20)
+						return icons.IconSearch.Layout(gtx, th.Fg)
+					}
+					return p.search.Layout(gtx, th, i18n.SearchLog.Value())
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+				layout.Flexed(1, func(gtx page.C) page.D {
+					if len(records) == 0 {
+						return material.Body1(th, i18n.LogsNone.Value()).Layout(gtx)
+					}
+
+					return material.List(th, &p.list).Layout(gtx, len(records), func(gtx page.C, index int) page.D {
+						return layoutRecord(gtx, th, &records[index])
+					})
+				}),
+			)
+		})
+	})
+}
+
+func layoutRecord(gtx page.C, th *page.T, r *config.LogRecord) page.D {
+	return layout.Inset{
+		Top:    4,
+		Bottom: 4,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.Flex{
+			Axis: layout.Vertical,
+		}.Layout(gtx,
+			layout.Rigid(func(gtx page.C) page.D {
+				return layout.Flex{
+					Alignment: layout.Baseline,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						label := material.Caption(th, r.Level.String())
+						label.Font.Weight = font.SemiBold
+						label.Color = levelColor(th, r.Level)
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(material.Caption(th, r.Time.Format("2006-01-02 15:04:05.000")).Layout),
+				)
+			}),
+			layout.Rigid(material.Body2(th, r.Msg).Layout),
+			layout.Rigid(func(gtx page.C) page.D {
+				if r.Attrs == "" {
+					return page.D{}
+				}
+				label := material.Caption(th, r.Attrs)
+				label.Color = color.NRGBA(colornames.Grey500)
+				return label.Layout(gtx)
+			}),
+		)
+	})
+}
+
+func levelColor(th *page.T, level slog.Level) color.NRGBA {
+	switch {
+	case level >= slog.LevelError:
+		return color.NRGBA(colornames.Red500)
+	case level >= slog.LevelWarn:
+		return color.NRGBA(colornames.Orange500)
+	case level < slog.LevelInfo:
+		return color.NRGBA(colornames.Grey500)
+	default:
+		return th.Fg
+	}
+}
+
+func (p *logPage) showLevelMenu(gtx page.C, title i18n.Key, selector *ui_widget.Selector, fn func(value string)) {
+	options := make([]ui_widget.MenuOption, len(levelOptions))
+	copy(options, levelOptions)
+	for i := range options {
+		options[i].Selected = selector.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = title
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				selector.Clear()
+				selector.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Value: p.menu.Options[i].Value})
+				if fn != nil {
+					fn(p.menu.Options[i].Value)
+				}
+				break
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = false
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
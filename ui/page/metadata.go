@@ -0,0 +1,34 @@
+package page
+
+import (
+	"github.com/go-gost/gostctl/api"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+)
+
+// MetadataFields returns the known metadata keys of the component type for the metadata dialog.
+func MetadataFields(kind api.MetadataKind, typ string) []ui_widget.MetadataField {
+	var fields []ui_widget.MetadataField
+	for _, f := range api.MetadataFields(kind, typ) {
+		field := ui_widget.MetadataField{
+			Key:     f.Key,
+			Type:    string(f.Type),
+			Default: f.Default,
+			Desc:    f.Desc,
+			Values:  f.Values,
+		}
+		if f.Type == api.BoolValue && len(f.Values) == 0 {
+			field.Choices = []string{"true", "false"}
+		}
+
+		// the type is checked regardless of the allowed values, which are checked by the dialog.
+		typeOnly := f
+		typeOnly.Values = nil
+		field.Check = func(v string) error {
+			_, err := typeOnly.Parse(v)
+			return err
+		}
+
+		fields = append(fields, field)
+	}
+	return fields
+}
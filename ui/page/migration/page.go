@@ -0,0 +1,262 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/api/runner/task"
+	"github.com/go-gost/gostctl/api/util"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+type migrationPage struct {
+	readonly bool
+	router   *page.Router
+	list     widget.List
+
+	btnBack  widget.Clickable
+	btnApply widget.Clickable
+
+	migrations []api.Migration
+
+	// sub receives the progress of the migration being applied.
+	sub      *runner.Subscription
+	progress *runner.Progress
+	applyErr error
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &migrationPage{
+		router: r,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
+		},
+	}
+}
+
+func (p *migrationPage) Init(opts ...page.PageOption) {
+	p.readonly = false
+	if server := config.CurrentServer(); server != nil {
+		p.readonly = server.IsReadonly()
+	}
+
+	p.Exit()
+	p.applyErr = nil
+
+	p.migrations = api.Migrate(api.GetConfig())
+}
+
+// Exit stops following the migration being applied, which goes on in the background.
+func (p *migrationPage) Exit() {
+	p.sub.Unsubscribe()
+	p.sub = nil
+	p.progress = nil
+}
+
+func (p *migrationPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+	if p.btnApply.Clicked(gtx) && p.sub == nil {
+		p.apply()
+	}
+	p.handleApplyEvent(gtx)
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Flexed(1, func(gtx page.C) page.D {
+						title := material.H6(th, i18n.Migration.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if p.readonly || len(p.migrations) == 0 || p.sub != nil {
+							return page.D{}
+						}
+
+						btn := material.IconButton(th, &p.btnApply, icons.IconDone, "Done")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.layoutProgress(gtx, th)
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return p.layout(gtx, th)
+			})
+		}),
+	)
+}
+
+func (p *migrationPage) layoutProgress(gtx page.C, th *page.T) page.D {
+	var label material.LabelStyle
+	switch {
+	case p.sub != nil:
+		text := i18n.Saving.Value()
+		if p.progress != nil {
+			if p.progress.Rollback {
+				text = i18n.MigrationRollback.Value()
+			}
+			text = fmt.Sprintf("%s %d/%d", text, p.progress.Step+1, p.progress.Steps)
+		}
+		label = material.Body2(th, text)
+	case p.applyErr != nil:
+		label = material.Body2(th, p.applyErr.Error())
+		label.Color = color.NRGBA(colornames.Red500)
+	default:
+		return page.D{}
+	}
+	return layout.Inset{Left: 16, Right: 16}.Layout(gtx, label.Layout)
+}
+
+func (p *migrationPage) handleApplyEvent(gtx page.C) {
+	if p.sub == nil {
+		return
+	}
+
+	for {
+		select {
+		case e, ok := <-p.sub.Event():
+			if !ok {
+				return
+			}
+			// the events of the steps report the progress, the plan is done with its own event.
+			if e.Progress != nil {
+				p.progress = e.Progress
+				continue
+			}
+
+			p.Exit()
+			util.RestartGetConfigTask()
+
+			p.applyErr = e.Err
+			if e.Err == nil {
+				p.router.Back()
+			}
+			return
+		default:
+			// poll until the plan is done.
+			gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(100 * time.Millisecond)})
+			return
+		}
+	}
+}
+
+func (p *migrationPage) layout(gtx page.C, th *page.T) page.D {
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			if len(p.migrations) == 0 {
+				return material.Body1(th, i18n.MigrationNone.Value()).Layout(gtx)
+			}
+
+			return material.List(th, &p.list).Layout(gtx, len(p.migrations)+1, func(gtx page.C, index int) page.D {
+				if index == 0 {
+					return layout.Inset{
+						Bottom: 16,
+					}.Layout(gtx, material.Body2(th, i18n.MigrationHint.Value()).Layout)
+				}
+
+				m := p.migrations[index-1]
+
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx page.C) page.D {
+						label := material.Body1(th, m.Kind+" "+m.Name)
+						label.Font.Weight = font.SemiBold
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Height: 4}.Layout),
+				}
+				for _, change := range m.Changes {
+					children = append(children, layout.Rigid(material.Body2(th, change).Layout))
+				}
+				children = append(children,
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						div := component.Divider(th)
+						return div.Layout(gtx)
+					}),
+				)
+
+				return layout.Inset{
+					Bottom: 8,
+				}.Layout(gtx, func(gtx page.C) page.D {
+					return layout.Flex{
+						Axis: layout.Vertical,
+					}.Layout(gtx, children...)
+				})
+			})
+		})
+	})
+}
+
+// apply updates all the migrated objects as a whole,
+// the objects already updated are restored if one of the updates fails.
+func (p *migrationPage) apply() {
+	var steps []runner.Step
+	for _, m := range p.migrations {
+		switch v := m.Value.(type) {
+		case *api.ServiceConfig:
+			steps = append(steps, task.UpdateServiceStep(v))
+		case *api.AdmissionConfig:
+			steps = append(steps, task.UpdateAdmissionStep(v))
+		case *api.BypassConfig:
+			steps = append(steps, task.UpdateBypassStep(v))
+		}
+	}
+
+	p.sub = runner.Subscribe(runner.FilterPlan(runner.TaskPlanMigration))
+	p.progress = nil
+	p.applyErr = nil
+
+	runner.Exec(context.Background(),
+		runner.NewPlan(runner.TaskPlanMigration, steps...),
+		runner.WithAync(true),
+		runner.WithCancel(true),
+		runner.WithInline(true),
+	)
+}
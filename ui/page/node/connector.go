@@ -27,6 +27,8 @@ type connector struct {
 	username   component.TextField
 	password   component.TextField
 
+	tls *page.TLSEditor
+
 	metadata          []metadata
 	mdSelector        ui_widget.Selector
 	mdFolded          bool
@@ -40,6 +42,7 @@ func newConnector(node *nodePage) *connector {
 		node:       node,
 		typ:        ui_widget.Selector{Title: i18n.Type},
 		enableAuth: ui_widget.Switcher{Title: i18n.Auth},
+		tls:        page.NewTLSEditor(node.router),
 		mdSelector: ui_widget.Selector{Title: i18n.Metadata},
 		mdDialog: ui_widget.MetadataDialog{
 			K: component.TextField{
@@ -85,6 +88,8 @@ func (p *connector) init(cfg *api.ConnectorConfig) {
 		}
 	}
 
+	p.tls.Init(cfg.TLS)
+
 	p.metadata = nil
 	md := api.NewMetadata(cfg.Metadata)
 	for k := range md {
@@ -159,6 +164,16 @@ func (p *connector) Layout(gtx page.C, th *page.T) page.D {
 			)
 		}),
 
+		// TLS config
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.Inset{
+				Top:    4,
+				Bottom: 4,
+			}.Layout(gtx, func(gtx page.C) page.D {
+				return p.tls.Layout(gtx, th)
+			})
+		}),
+
 		layout.Rigid(func(gtx page.C) page.D {
 			if p.mdAdd.Clicked(gtx) {
 				p.showMetadataDialog(gtx, -1)
@@ -293,6 +308,7 @@ func (p *connector) layoutMetadata(gtx page.C, th *page.T) page.D {
 func (p *connector) showMetadataDialog(gtx page.C, i int) {
 	p.mdDialog.K.Clear()
 	p.mdDialog.V.Clear()
+	p.mdDialog.Fields = page.MetadataFields(api.ConnectorMetadata, p.typ.Value())
 
 	if i >= 0 && i < len(p.metadata) {
 		p.mdDialog.K.SetText(p.metadata[i].k)
@@ -27,12 +27,7 @@ type dialer struct {
 	username   component.TextField
 	password   component.TextField
 
-	enableTLS     ui_widget.Switcher
-	tlsSecure     ui_widget.Switcher
-	tlsServerName component.TextField
-	tlsCertFile   component.TextField
-	tlsKeyFile    component.TextField
-	tlsCAFile     component.TextField
+	tls *page.TLSEditor
 
 	metadata          []metadata
 	mdSelector        ui_widget.Selector
@@ -47,32 +42,7 @@ func newDialer(node *nodePage) *dialer {
 		node:       node,
 		typ:        ui_widget.Selector{Title: i18n.Type},
 		enableAuth: ui_widget.Switcher{Title: i18n.Auth},
-		enableTLS:  ui_widget.Switcher{Title: i18n.TLS},
-		tlsSecure:  ui_widget.Switcher{Title: i18n.VerifyServerCert},
-		tlsServerName: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		tlsCertFile: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		tlsKeyFile: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		tlsCAFile: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
+		tls:        page.NewTLSEditor(node.router),
 		mdSelector: ui_widget.Selector{Title: i18n.Metadata},
 		mdDialog: ui_widget.MetadataDialog{
 			K: component.TextField{
@@ -118,24 +88,7 @@ func (p *dialer) init(cfg *api.DialerConfig) {
 		}
 	}
 
-	{
-		p.enableTLS.SetValue(false)
-		p.tlsCertFile.Clear()
-		p.tlsKeyFile.Clear()
-		p.tlsCAFile.Clear()
-		p.tlsSecure.SetValue(false)
-		p.tlsServerName.Clear()
-
-		if tls := cfg.TLS; tls != nil {
-			p.enableTLS.SetValue(true)
-			p.tlsCertFile.SetText(tls.CertFile)
-			p.tlsKeyFile.SetText(tls.KeyFile)
-			p.tlsCAFile.SetText(tls.CAFile)
-
-			p.tlsSecure.SetValue(tls.Secure)
-			p.tlsServerName.SetText(tls.ServerName)
-		}
-	}
+	p.tls.Init(cfg.TLS)
 
 	p.metadata = nil
 	md := api.NewMetadata(cfg.Metadata)
@@ -222,59 +175,7 @@ func (p *dialer) Layout(gtx page.C, th *page.T) page.D {
 				Top:    4,
 				Bottom: 4,
 			}.Layout(gtx, func(gtx page.C) page.D {
-				return layout.Flex{
-					Axis: layout.Vertical,
-				}.Layout(gtx,
-					layout.Rigid(func(gtx page.C) page.D {
-						return p.enableTLS.Layout(gtx, th)
-					}),
-
-					layout.Rigid(func(gtx page.C) page.D {
-						if !p.enableTLS.Value() {
-							return page.D{}
-						}
-						return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
-							return layout.Flex{
-								Axis: layout.Vertical,
-							}.Layout(gtx,
-								layout.Rigid(func(gtx page.C) page.D {
-									return p.tlsSecure.Layout(gtx, th)
-								}),
-
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.ServerName.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return p.tlsServerName.Layout(gtx, th, "")
-								}),
-								layout.Rigid(layout.Spacer{Height: 8}.Layout),
-
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.CertFile.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return p.tlsCertFile.Layout(gtx, th, "")
-								}),
-								layout.Rigid(layout.Spacer{Height: 8}.Layout),
-
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.KeyFile.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return p.tlsKeyFile.Layout(gtx, th, "")
-								}),
-								layout.Rigid(layout.Spacer{Height: 8}.Layout),
-
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.CAFile.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return p.tlsCAFile.Layout(gtx, th, "")
-								}),
-							)
-						})
-					}),
-				)
+				return p.tls.Layout(gtx, th)
 			})
 		}),
 		layout.Rigid(func(gtx page.C) page.D {
@@ -411,6 +312,7 @@ func (p *dialer) layoutMetadata(gtx page.C, th *page.T) page.D {
 func (p *dialer) showMetadataDialog(gtx page.C, i int) {
 	p.mdDialog.K.Clear()
 	p.mdDialog.V.Clear()
+	p.mdDialog.Fields = page.MetadataFields(api.DialerMetadata, p.typ.Value())
 
 	if i >= 0 && i < len(p.metadata) {
 		p.mdDialog.K.SetText(p.metadata[i].k)
@@ -41,6 +41,7 @@ type nodePage struct {
 	bypass     ui_widget.Selector
 	resolver   ui_widget.Selector
 	hostMapper ui_widget.Selector
+	http       *page.HTTPNodeEditor
 
 	connector *connector
 	dialer    *dialer
@@ -79,6 +80,7 @@ func NewPage(r *page.Router) page.Page {
 		bypass:     ui_widget.Selector{Title: i18n.Bypass},
 		resolver:   ui_widget.Selector{Title: i18n.Resolver},
 		hostMapper: ui_widget.Selector{Title: i18n.Hosts},
+		http:       page.NewHTTPNodeEditor(r),
 
 		delDialog: ui_widget.Dialog{Title: i18n.DeleteNode},
 	}
@@ -142,6 +144,8 @@ func (p *nodePage) Init(opts ...page.PageOption) {
 		p.hostMapper.Select(ui_widget.SelectorItem{Value: node.Hosts})
 	}
 
+	p.http.Init(node.HTTP)
+
 	p.connector.init(node.Connector)
 	p.dialer.init(node.Dialer)
 }
@@ -316,6 +320,10 @@ func (p *nodePage) layout(gtx page.C, th *page.T) page.D {
 							return p.hostMapper.Layout(gtx, th)
 						}),
 
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return p.http.Layout(gtx, th)
+						}),
+
 						layout.Rigid(layout.Spacer{Height: 8}.Layout),
 					)
 				}),
@@ -472,14 +480,16 @@ func (p *nodePage) generateConfig() *api.NodeConfig {
 		Bypasses: p.bypass.Values(),
 		Resolver: p.resolver.Value(),
 		Hosts:    p.hostMapper.Value(),
+		HTTP:     p.http.Config(),
 	}
 
 	connector := &api.ConnectorConfig{
 		Type:     p.connector.typ.Value(),
 		Metadata: make(map[string]any),
 	}
-	for i := range p.dialer.metadata {
-		connector.Metadata[p.connector.metadata[i].k] = p.connector.metadata[i].v
+	for i := range p.connector.metadata {
+		md := &p.connector.metadata[i]
+		connector.Metadata[md.k] = api.MetadataValue(api.ConnectorMetadata, connector.Type, md.k, md.v)
 	}
 
 	if p.connector.enableAuth.Value() {
@@ -488,6 +498,7 @@ func (p *nodePage) generateConfig() *api.NodeConfig {
 			Password: strings.TrimSpace(p.connector.password.Text()),
 		}
 	}
+	connector.TLS = p.connector.tls.Config()
 	node.Connector = connector
 
 	dialer := &api.DialerConfig{
@@ -495,7 +506,8 @@ func (p *nodePage) generateConfig() *api.NodeConfig {
 		Metadata: make(map[string]any),
 	}
 	for i := range p.dialer.metadata {
-		dialer.Metadata[p.dialer.metadata[i].k] = p.dialer.metadata[i].v
+		md := &p.dialer.metadata[i]
+		dialer.Metadata[md.k] = api.MetadataValue(api.DialerMetadata, dialer.Type, md.k, md.v)
 	}
 
 	if p.dialer.enableAuth.Value() {
@@ -504,15 +516,7 @@ func (p *nodePage) generateConfig() *api.NodeConfig {
 			Password: strings.TrimSpace(p.dialer.password.Text()),
 		}
 	}
-	if p.dialer.enableTLS.Value() {
-		dialer.TLS = &api.TLSConfig{
-			Secure:     p.dialer.tlsSecure.Value(),
-			ServerName: strings.TrimSpace(p.dialer.tlsServerName.Text()),
-			CertFile:   strings.TrimSpace(p.dialer.tlsCertFile.Text()),
-			KeyFile:    strings.TrimSpace(p.dialer.tlsKeyFile.Text()),
-			CAFile:     strings.TrimSpace(p.dialer.tlsCAFile.Text()),
-		}
-	}
+	dialer.TLS = p.dialer.tls.Config()
 	node.Dialer = dialer
 
 	return node
@@ -10,30 +10,38 @@ import (
 type PagePath string
 
 const (
-	PageHome          PagePath = "/"
-	PageServer        PagePath = "/server"
-	PageService       PagePath = "/service"
-	PageServiceRecord PagePath = "/service/record"
-	PageChain         PagePath = "/chain"
-	PageHop           PagePath = "/hop"
-	PageNode          PagePath = "/node"
-	PageForwarderNode PagePath = "/forwarder/node"
-	PageAuther        PagePath = "/auther"
-	PageAutherAuths   PagePath = "/auther/auths"
-	PageMatcher       PagePath = "/matcher"
-	PageAdmission     PagePath = "/admission"
-	PageBypass        PagePath = "/bypass"
-	PageResolver      PagePath = "/resolver"
-	PageNameServer    PagePath = "/resolver/nameserver"
-	PageHosts         PagePath = "/hosts"
-	PageHostMapping   PagePath = "/hosts/mapping"
-	PageLimiter       PagePath = "/limiter"
-	PageLimit         PagePath = "/limiter/limit"
-	PageObserver      PagePath = "/observer"
-	PageRecorder      PagePath = "/recorder"
-	PageEvent         PagePath = "/event"
-	PageConfig        PagePath = "/config"
-	PageSettings      PagePath = "/settings"
+	PageHome           PagePath = "/"
+	PageServer         PagePath = "/server"
+	PageService        PagePath = "/service"
+	PageServiceRecord  PagePath = "/service/record"
+	PageChain          PagePath = "/chain"
+	PageHop            PagePath = "/hop"
+	PageNode           PagePath = "/node"
+	PageForwarderNode  PagePath = "/forwarder/node"
+	PageAuther         PagePath = "/auther"
+	PageAutherAuths    PagePath = "/auther/auths"
+	PageMatcher        PagePath = "/matcher"
+	PageAdmission      PagePath = "/admission"
+	PageBypass         PagePath = "/bypass"
+	PageResolver       PagePath = "/resolver"
+	PageNameServer     PagePath = "/resolver/nameserver"
+	PageHosts          PagePath = "/hosts"
+	PageHostMapping    PagePath = "/hosts/mapping"
+	PageLimiter        PagePath = "/limiter"
+	PageLimit          PagePath = "/limiter/limit"
+	PageObserver       PagePath = "/observer"
+	PageRecorder       PagePath = "/recorder"
+	PageEvent          PagePath = "/event"
+	PageConfig         PagePath = "/config"
+	PageSettings       PagePath = "/settings"
+	PageServerSettings PagePath = "/server/settings"
+	PageMigration      PagePath = "/migration"
+	PageActivity       PagePath = "/activity"
+	PagePending        PagePath = "/pending"
+	PageSchedules      PagePath = "/server/schedules"
+	PageSchedule       PagePath = "/server/schedule"
+	PageServerTransfer PagePath = "/server/transfer"
+	PageLog            PagePath = "/log"
 )
 
 type Perm uint8
@@ -99,6 +107,12 @@ type Page interface {
 	Layout(gtx C) D
 }
 
+// Exiter is implemented by the pages that release resources, such as event subscriptions,
+// when they are left.
+type Exiter interface {
+	Exit()
+}
+
 type PageMode string
 
 const (
@@ -126,6 +140,26 @@ var (
 		{Key: i18n.SelectorFIFO, Value: "fifo"},
 	}
 
+	ScheduleActionOptions = []ui_widget.MenuOption{
+		{Key: i18n.ScheduleCreate, Value: "create"},
+		{Key: i18n.ScheduleUpdate, Value: "update"},
+		{Key: i18n.ScheduleDelete, Value: "delete"},
+		{Key: i18n.ScheduleSave, Value: "save"},
+	}
+	ResourceKindOptions = []ui_widget.MenuOption{
+		{Key: i18n.Service, Value: "service"},
+		{Key: i18n.Chain, Value: "chain"},
+		{Key: i18n.Hop, Value: "hop"},
+		{Key: i18n.Auther, Value: "auther"},
+		{Key: i18n.Admission, Value: "admission"},
+		{Key: i18n.Bypass, Value: "bypass"},
+		{Key: i18n.Resolver, Value: "resolver"},
+		{Key: i18n.Hosts, Value: "hosts"},
+		{Key: i18n.Limiter, Value: "limiter"},
+		{Key: i18n.Observer, Value: "observer"},
+		{Key: i18n.Recorder, Value: "recorder"},
+	}
+
 	PluginTypeOptions = []ui_widget.MenuOption{
 		{Key: i18n.PluginGRPC, Value: "grpc"},
 		{Key: i18n.PluginHTTP, Value: "http"},
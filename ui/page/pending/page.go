@@ -0,0 +1,260 @@
+package pending
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api/queue"
+	"github.com/go-gost/gostctl/api/runner/task"
+	"github.com/go-gost/gostctl/api/util"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+type changeState struct {
+	discard   widget.Clickable
+	overwrite widget.Clickable
+	retry     widget.Clickable
+}
+
+type pendingPage struct {
+	readonly bool
+	router   *page.Router
+	list     widget.List
+
+	btnBack widget.Clickable
+
+	server  string
+	changes []queue.Change
+	states  map[string]*changeState
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &pendingPage{
+		router: r,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
+		},
+		states: make(map[string]*changeState),
+	}
+}
+
+func (p *pendingPage) Init(opts ...page.PageOption) {
+	p.readonly = false
+	p.server = ""
+	if server := config.CurrentServer(); server != nil {
+		p.readonly = server.IsReadonly()
+		p.server = server.Name
+	}
+	clear(p.states)
+}
+
+func (p *pendingPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+
+	p.changes = queue.List(p.server)
+	p.update(gtx)
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						title := material.H6(th, i18n.PendingChanges.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return p.layout(gtx, th)
+			})
+		}),
+	)
+}
+
+// update handles the clicked buttons of the changes and drops the states of the changes no longer queued.
+func (p *pendingPage) update(gtx page.C) {
+	ids := make(map[string]bool, len(p.changes))
+	for _, c := range p.changes {
+		ids[c.ID] = true
+
+		state := p.states[c.ID]
+		if state == nil {
+			state = &changeState{}
+			p.states[c.ID] = state
+		}
+		if p.readonly {
+			continue
+		}
+
+		if state.discard.Clicked(gtx) {
+			queue.Remove(p.server, c.ID)
+			util.ReplayQueue()
+		}
+		if state.overwrite.Clicked(gtx) {
+			queue.Update(p.server, task.Rebase(c))
+			util.ReplayQueue()
+		}
+		if state.retry.Clicked(gtx) {
+			c.Failed = ""
+			queue.Update(p.server, c)
+			util.ReplayQueue()
+		}
+	}
+
+	for id := range p.states {
+		if !ids[id] {
+			delete(p.states, id)
+		}
+	}
+}
+
+func (p *pendingPage) layout(gtx page.C, th *page.T) page.D {
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			if len(p.changes) == 0 {
+				return material.Body1(th, i18n.PendingChangesNone.Value()).Layout(gtx)
+			}
+
+			return material.List(th, &p.list).Layout(gtx, len(p.changes)+1, func(gtx page.C, index int) page.D {
+				if index == 0 {
+					return layout.Inset{
+						Bottom: 16,
+					}.Layout(gtx, material.Body2(th, i18n.PendingChangesHint.Value()).Layout)
+				}
+
+				c := p.changes[index-1]
+				return layout.Inset{
+					Bottom: 8,
+				}.Layout(gtx, func(gtx page.C) page.D {
+					return p.layoutChange(gtx, th, c, p.states[c.ID])
+				})
+			})
+		})
+	})
+}
+
+func (p *pendingPage) layoutChange(gtx page.C, th *page.T, c queue.Change, state *changeState) page.D {
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			label := material.Body1(th, fmt.Sprintf("%s %s %s", c.Op, c.Kind, c.Name))
+			label.Font.Weight = font.SemiBold
+			return label.Layout(gtx)
+		}),
+		layout.Rigid(layout.Spacer{Height: 4}.Layout),
+		layout.Rigid(material.Body2(th, c.Time.Local().Format(time.DateTime)).Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			if c.Conflict == "" {
+				return page.D{}
+			}
+			label := material.Body2(th, c.Conflict)
+			label.Color = color.NRGBA(colornames.Red500)
+			return layout.Inset{Top: 4}.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if c.Failed == "" {
+				return page.D{}
+			}
+			label := material.Body2(th, c.Failed)
+			label.Color = color.NRGBA(colornames.Red500)
+			return layout.Inset{Top: 4}.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if p.readonly {
+				return page.D{}
+			}
+
+			return layout.Inset{Top: 8}.Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						if c.Conflict == "" {
+							return page.D{}
+						}
+						return layout.Inset{Right: 8}.Layout(gtx, func(gtx page.C) page.D {
+							return layoutButton(gtx, th, &state.overwrite, i18n.Overwrite)
+						})
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if c.Failed == "" {
+							return page.D{}
+						}
+						return layout.Inset{Right: 8}.Layout(gtx, func(gtx page.C) page.D {
+							return layoutButton(gtx, th, &state.retry, i18n.Retry)
+						})
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						return layoutButton(gtx, th, &state.discard, i18n.Discard)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(layout.Spacer{Height: 8}.Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			div := component.Divider(th)
+			return div.Layout(gtx)
+		}),
+	)
+}
+
+func layoutButton(gtx page.C, th *page.T, btn *widget.Clickable, text i18n.Key) page.D {
+	return material.ButtonLayoutStyle{
+		Background:   th.Bg,
+		CornerRadius: 18,
+		Button:       btn,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.Inset{
+			Top:    4,
+			Bottom: 4,
+			Left:   16,
+			Right:  16,
+		}.Layout(gtx, func(gtx page.C) page.D {
+			label := material.Body2(th, text.Value())
+			label.Color = th.Fg
+			return label.Layout(gtx)
+		})
+	})
+}
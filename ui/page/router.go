@@ -80,7 +80,9 @@ func (r *Router) Goto(route Route) {
 }
 
 func (r *Router) Back() (page Page) {
-	r.stack.Pop()
+	if exiter, ok := r.pages[r.stack.Pop().Path].(Exiter); ok {
+		exiter.Exit()
+	}
 	route := r.stack.Peek()
 
 	page = r.pages[route.Path]
@@ -12,6 +12,7 @@ import (
 	"gioui.org/widget/material"
 	"gioui.org/x/component"
 	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/api/queue"
 	"github.com/go-gost/gostctl/api/util"
 	"github.com/go-gost/gostctl/config"
 	"github.com/go-gost/gostctl/ui/i18n"
@@ -31,22 +32,30 @@ type serverPage struct {
 	btnEdit   widget.Clickable
 	btnSave   widget.Clickable
 
-	btnEvent  widget.Clickable
-	btnConfig widget.Clickable
+	btnEvent    widget.Clickable
+	btnConfig   widget.Clickable
+	btnSettings widget.Clickable
+	btnMigrate  widget.Clickable
+	btnPending  widget.Clickable
+	btnSchedule widget.Clickable
 
 	list layout.List
 
-	name component.TextField
-	url  component.TextField
+	name  component.TextField
+	group component.TextField
+	tags  component.TextField
+	url   component.TextField
 
 	basicAuth          ui_widget.Switcher
 	username           component.TextField
 	password           component.TextField
 	btnPasswordVisible widget.Clickable
 	passwordVisible    bool
+	sealedPassword     string
 
-	interval component.TextField
-	timeout  component.TextField
+	interval           component.TextField
+	backgroundInterval component.TextField
+	timeout            component.TextField
 
 	autoSave ui_widget.Switcher
 	saveFile component.TextField
@@ -76,6 +85,18 @@ func NewPage(r *page.Router) page.Page {
 				MaxLen:     128,
 			},
 		},
+		group: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     128,
+			},
+		},
+		tags: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
 		basicAuth: ui_widget.Switcher{Title: i18n.BasicAuth},
 		username: component.TextField{
 			Editor: widget.Editor{
@@ -97,6 +118,14 @@ func NewPage(r *page.Router) page.Page {
 			},
 			Suffix: material.Body1(r.Theme, i18n.TimeSecond.Value()).Layout,
 		},
+		backgroundInterval: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     16,
+				Filter:     "1234567890",
+			},
+			Suffix: material.Body1(r.Theme, i18n.TimeSecond.Value()).Layout,
+		},
 		timeout: component.TextField{
 			Editor: widget.Editor{
 				SingleLine: true,
@@ -151,10 +180,21 @@ func (p *serverPage) Init(opts ...page.PageOption) {
 			break
 		}
 	}
+	// a new server is filled with the value, e.g. parsed from a server link.
+	if v, _ := options.Value.(*config.Server); v != nil && p.create {
+		server = v
+		server.Name = config.UniqueServerName(cfg.Servers, v.Name)
+	}
 
 	p.name.Clear()
 	p.name.SetText(server.Name)
 
+	p.group.Clear()
+	p.group.SetText(server.Group)
+
+	p.tags.Clear()
+	p.tags.SetText(strings.Join(server.Tags, ", "))
+
 	p.url.Clear()
 	p.url.SetText(server.URL)
 
@@ -165,12 +205,26 @@ func (p *serverPage) Init(opts ...page.PageOption) {
 	}
 
 	p.username.SetText(server.Username)
-	p.password.SetText(server.Password)
+	// the sealed password is kept if the secrets are locked and the password is not changed.
+	p.sealedPassword = ""
+	password, err := server.Secret()
+	if err != nil {
+		p.sealedPassword = server.Password
+	}
+	p.password.SetText(password)
 	p.passwordVisible = false
+	// the password of a server from a link is not in the link, it is asked for here.
+	p.password.Helper = ""
+	if v, _ := options.Value.(*config.Server); v != nil && p.create && server.Username != "" && password == "" {
+		p.password.Helper = i18n.LinkPasswordHint.Value()
+	}
 
 	p.interval.Clear()
 	p.interval.SetText(fmt.Sprintf("%d", int(server.Interval.Seconds())))
 
+	p.backgroundInterval.Clear()
+	p.backgroundInterval.SetText(fmt.Sprintf("%d", int(server.BackgroundInterval.Seconds())))
+
 	p.timeout.Clear()
 	p.timeout.SetText(fmt.Sprintf("%d", int(server.Timeout.Seconds())))
 
@@ -316,6 +370,26 @@ func (p *serverPage) layout(gtx page.C, th *page.T) page.D {
 			Value: api.GetConfig(),
 		})
 	}
+	if p.btnSettings.Clicked(gtx) {
+		p.router.Goto(page.Route{
+			Path: page.PageServerSettings,
+		})
+	}
+	if p.btnPending.Clicked(gtx) {
+		p.router.Goto(page.Route{
+			Path: page.PagePending,
+		})
+	}
+	if p.btnSchedule.Clicked(gtx) {
+		p.router.Goto(page.Route{
+			Path: page.PageSchedules,
+		})
+	}
+	if p.btnMigrate.Clicked(gtx) {
+		p.router.Goto(page.Route{
+			Path: page.PageMigration,
+		})
+	}
 	if p.btnEvent.Clicked(gtx) {
 		server := &config.Server{}
 		for _, srv := range config.Get().Servers {
@@ -378,6 +452,50 @@ func (p *serverPage) layout(gtx page.C, th *page.T) page.D {
 								return page.D{}
 							}
 
+							btn := material.IconButton(th, &p.btnSchedule, icons.IconSchedule, "Schedules")
+							btn.Color = th.Fg
+							btn.Background = theme.Current().ContentSurfaceBg
+							return btn.Layout(gtx)
+						}),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							if !p.active || len(queue.List(p.id)) == 0 {
+								return page.D{}
+							}
+
+							btn := material.IconButton(th, &p.btnPending, icons.IconActionHourGlassEmpty, "Pending")
+							btn.Color = th.Fg
+							btn.Background = theme.Current().ContentSurfaceBg
+							return btn.Layout(gtx)
+						}),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							if !p.active || len(api.Migrate(api.GetConfig())) == 0 {
+								return page.D{}
+							}
+
+							btn := material.IconButton(th, &p.btnMigrate, icons.IconActionUpdate, "Migrate")
+							btn.Color = th.Fg
+							btn.Background = theme.Current().ContentSurfaceBg
+							return btn.Layout(gtx)
+						}),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							if !p.active {
+								return page.D{}
+							}
+
+							btn := material.IconButton(th, &p.btnSettings, icons.IconSettings, "Settings")
+							btn.Color = th.Fg
+							btn.Background = theme.Current().ContentSurfaceBg
+							return btn.Layout(gtx)
+						}),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							if !p.active {
+								return page.D{}
+							}
+
 							btn := material.IconButton(th, &p.btnConfig, icons.IconCode, "Config")
 							btn.Color = th.Fg
 							btn.Background = theme.Current().ContentSurfaceBg
@@ -395,6 +513,32 @@ func (p *serverPage) layout(gtx page.C, th *page.T) page.D {
 					return p.name.Layout(gtx, th, "")
 				}),
 				layout.Rigid(layout.Spacer{Height: 16}.Layout),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					return material.Body1(th, i18n.Group.Value()).Layout(gtx)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.group.Layout(gtx, th, "")
+				}),
+				layout.Rigid(layout.Spacer{Height: 16}.Layout),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.Flex{
+						Alignment: layout.Baseline,
+					}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return material.Body1(th, i18n.Tags.Value()).Layout(gtx)
+						}),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return material.Body2(th, "("+i18n.TagsHint.Value()+")").Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.tags.Layout(gtx, th, "")
+				}),
+				layout.Rigid(layout.Spacer{Height: 16}.Layout),
 				layout.Rigid(func(gtx page.C) page.D {
 					return layout.Flex{
 						Alignment: layout.Baseline,
@@ -431,6 +575,24 @@ func (p *serverPage) layout(gtx page.C, th *page.T) page.D {
 				}),
 				layout.Rigid(layout.Spacer{Height: 16}.Layout),
 
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.Flex{
+						Alignment: layout.Baseline,
+					}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return material.Body1(th, i18n.BackgroundInterval.Value()).Layout(gtx)
+						}),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return material.Body2(th, "("+i18n.BackgroundIntervalHint.Value()+")").Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.backgroundInterval.Layout(gtx, th, "")
+				}),
+				layout.Rigid(layout.Spacer{Height: 16}.Layout),
+
 				layout.Rigid(func(gtx page.C) page.D {
 					return layout.Flex{
 						Alignment: layout.Baseline,
@@ -534,17 +696,25 @@ func (p *serverPage) activate() {
 
 func (p *serverPage) save() bool {
 	server := &config.Server{
-		Name: strings.TrimSpace(p.name.Text()),
-		URL:  strings.TrimSpace(p.url.Text()),
+		Name:  strings.TrimSpace(p.name.Text()),
+		Group: strings.TrimSpace(p.group.Text()),
+		Tags:  config.ParseTags(p.tags.Text()),
+		URL:   strings.TrimSpace(p.url.Text()),
 	}
 
 	if p.basicAuth.Value() {
 		server.Username = strings.TrimSpace(p.username.Text())
 		server.Password = strings.TrimSpace(p.password.Text())
+		if server.Password == "" {
+			server.Password = p.sealedPassword
+		}
 	}
 	if interval, _ := strconv.Atoi(strings.TrimSpace(p.interval.Text())); interval > 0 {
 		server.Interval = time.Duration(interval) * time.Second
 	}
+	if interval, _ := strconv.Atoi(strings.TrimSpace(p.backgroundInterval.Text())); interval > 0 {
+		server.BackgroundInterval = time.Duration(interval) * time.Second
+	}
 	if timeout, _ := strconv.Atoi(strings.TrimSpace(p.timeout.Text())); timeout > 0 {
 		server.Timeout = time.Duration(timeout) * time.Second
 	}
@@ -591,6 +761,9 @@ func (p *serverPage) save() bool {
 	} else {
 		for i := range servers {
 			if servers[i].Name == server.Name {
+				// the schedules are edited on their own page.
+				server.Schedules = servers[i].Schedules
+				server.Extra = servers[i].Extra
 				servers[i] = server
 			}
 		}
@@ -0,0 +1,493 @@
+package schedule
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/api/runner/task"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+)
+
+type schedulePage struct {
+	router *page.Router
+
+	menu ui_widget.Menu
+	list layout.List
+
+	btnBack   widget.Clickable
+	btnDelete widget.Clickable
+	btnEdit   widget.Clickable
+	btnSave   widget.Clickable
+	btnLoad   widget.Clickable
+
+	name    component.TextField
+	enabled ui_widget.Switcher
+	cron    component.TextField
+	action  ui_widget.Selector
+	kind    ui_widget.Selector
+	target  component.TextField
+	value   component.TextField
+	// sealedValue is the value which can not be opened while the secrets are locked, it is kept if the value is not entered again.
+	sealedValue string
+
+	id       string
+	perm     page.Perm
+	callback page.Callback
+
+	edit   bool
+	create bool
+
+	delDialog ui_widget.Dialog
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &schedulePage{
+		router: r,
+
+		list: layout.List{
+			// NOTE: the list must be vertical
+			Axis: layout.Vertical,
+		},
+		name: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     128,
+			},
+		},
+		enabled: ui_widget.Switcher{Title: i18n.ScheduleEnabled},
+		cron: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     128,
+			},
+		},
+		action: ui_widget.Selector{Title: i18n.ScheduleAction},
+		kind:   ui_widget.Selector{Title: i18n.ScheduleKind},
+		target: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		value: component.TextField{
+			Editor: widget.Editor{},
+		},
+		delDialog: ui_widget.Dialog{
+			Title: i18n.DeleteSchedule,
+		},
+	}
+}
+
+func (p *schedulePage) Init(opts ...page.PageOption) {
+	var options page.PageOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	p.id = options.ID
+	p.perm = options.Perm
+	p.callback = options.Callback
+
+	if p.id != "" {
+		p.edit = false
+		p.create = false
+		p.name.ReadOnly = true
+	} else {
+		p.edit = true
+		p.create = true
+		p.name.ReadOnly = false
+	}
+
+	s, _ := options.Value.(*config.Schedule)
+	if s == nil {
+		s = &config.Schedule{
+			Action: config.ScheduleUpdate,
+			Kind:   "service",
+		}
+	}
+
+	p.name.Clear()
+	p.name.SetText(s.Name)
+
+	p.enabled.SetValue(!s.Disabled)
+
+	p.cron.Clear()
+	p.cron.SetText(s.Cron)
+
+	p.action.Clear()
+	for i := range page.ScheduleActionOptions {
+		if page.ScheduleActionOptions[i].Value == string(s.Action) {
+			p.action.Select(ui_widget.SelectorItem{Key: page.ScheduleActionOptions[i].Key, Value: page.ScheduleActionOptions[i].Value})
+			break
+		}
+	}
+
+	p.kind.Clear()
+	for i := range page.ResourceKindOptions {
+		if page.ResourceKindOptions[i].Value == s.Kind {
+			p.kind.Select(ui_widget.SelectorItem{Key: page.ResourceKindOptions[i].Key, Value: page.ResourceKindOptions[i].Value})
+			break
+		}
+	}
+
+	p.target.Clear()
+	p.target.SetText(s.Target)
+
+	p.value.Clear()
+	p.sealedValue = ""
+	value, err := s.PlainValue()
+	if err != nil {
+		p.sealedValue = s.Value
+	}
+	p.value.SetText(value)
+}
+
+func (p *schedulePage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+	if p.btnEdit.Clicked(gtx) {
+		p.edit = true
+	}
+	if p.btnSave.Clicked(gtx) {
+		if p.save() {
+			p.router.Back()
+		}
+	}
+
+	if p.btnDelete.Clicked(gtx) {
+		p.delDialog.OnClick = func(ok bool) {
+			if ok {
+				p.delete()
+				p.router.Back()
+			}
+			p.router.HideModal(gtx)
+		}
+
+		p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+			return p.delDialog.Layout(gtx, th)
+		})
+	}
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Flexed(1, func(gtx page.C) page.D {
+						title := material.H6(th, i18n.Schedules.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if p.perm&page.PermDelete == 0 || p.create {
+							return page.D{}
+						}
+						btn := material.IconButton(th, &p.btnDelete, icons.IconDelete, "Delete")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						if p.perm&page.PermWrite == 0 {
+							return page.D{}
+						}
+
+						if p.edit {
+							btn := material.IconButton(th, &p.btnSave, icons.IconDone, "Done")
+							btn.Color = th.Fg
+							btn.Background = th.Bg
+							return btn.Layout(gtx)
+						}
+						btn := material.IconButton(th, &p.btnEdit, icons.IconEdit, "Edit")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return p.list.Layout(gtx, 1, func(gtx page.C, _ int) page.D {
+				return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+					return p.layout(gtx, th)
+				})
+			})
+		}),
+	)
+}
+
+func (p *schedulePage) layout(gtx page.C, th *page.T) page.D {
+	if p.btnLoad.Clicked(gtx) {
+		p.load()
+	}
+
+	if !p.edit {
+		gtx = gtx.Disabled()
+	}
+
+	action := config.ScheduleAction(p.action.Value())
+
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx,
+				layout.Rigid(material.Body1(th, i18n.Name.Value()).Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.name.Layout(gtx, th, "")
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.enabled.Layout(gtx, th)
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.Flex{
+						Alignment: layout.Baseline,
+					}.Layout(gtx,
+						layout.Rigid(material.Body1(th, i18n.Cron.Value()).Layout),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(material.Body2(th, "("+i18n.CronHint.Value()+")").Layout),
+					)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.cron.Layout(gtx, th, "")
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					cron, err := runner.ParseCron(p.cron.Text())
+					if err != nil {
+						return page.D{}
+					}
+					next := cron.Next(time.Now())
+					if next.IsZero() {
+						return page.D{}
+					}
+					return layout.Inset{Top: 4}.Layout(gtx,
+						material.Caption(th, i18n.NextRun.Value()+": "+next.Format(time.DateTime)).Layout)
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					if p.action.Clicked(gtx) {
+						p.showMenu(gtx, i18n.ScheduleAction, page.ScheduleActionOptions, &p.action)
+					}
+					return p.action.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					if action == config.ScheduleSave {
+						return page.D{}
+					}
+					if p.kind.Clicked(gtx) {
+						p.showMenu(gtx, i18n.ScheduleKind, page.ResourceKindOptions, &p.kind)
+					}
+					return p.kind.Layout(gtx, th)
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.Flex{
+						Alignment: layout.Baseline,
+					}.Layout(gtx,
+						layout.Rigid(material.Body1(th, i18n.ScheduleTarget.Value()).Layout),
+						layout.Rigid(layout.Spacer{Width: 4}.Layout),
+						layout.Rigid(material.Body2(th, "("+i18n.ScheduleTargetHint.Value()+")").Layout),
+					)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.target.Layout(gtx, th, "")
+				}),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					if action != config.ScheduleCreate && action != config.ScheduleUpdate {
+						return page.D{}
+					}
+
+					return layout.Inset{Top: 16}.Layout(gtx, func(gtx page.C) page.D {
+						return layout.Flex{
+							Axis: layout.Vertical,
+						}.Layout(gtx,
+							layout.Rigid(func(gtx page.C) page.D {
+								return layout.Flex{
+									Alignment: layout.Middle,
+								}.Layout(gtx,
+									layout.Rigid(material.Body1(th, i18n.ScheduleValue.Value()).Layout),
+									layout.Rigid(layout.Spacer{Width: 4}.Layout),
+									layout.Flexed(1, material.Body2(th, "("+i18n.ScheduleValueHint.Value()+")").Layout),
+									layout.Rigid(func(gtx page.C) page.D {
+										return material.ButtonLayoutStyle{
+											Background:   th.Bg,
+											CornerRadius: 18,
+											Button:       &p.btnLoad,
+										}.Layout(gtx, func(gtx page.C) page.D {
+											return layout.Inset{
+												Top:    4,
+												Bottom: 4,
+												Left:   16,
+												Right:  16,
+											}.Layout(gtx, func(gtx page.C) page.D {
+												label := material.Body2(th, i18n.ScheduleLoad.Value())
+												label.Color = th.Fg
+												return label.Layout(gtx)
+											})
+										})
+									}),
+								)
+							}),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.value.Layout(gtx, th, "")
+							}),
+						)
+					})
+				}),
+			)
+		})
+	})
+}
+
+func (p *schedulePage) showMenu(gtx page.C, title i18n.Key, options []ui_widget.MenuOption, selector *ui_widget.Selector) {
+	for i := range options {
+		options[i].Selected = selector.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = title
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		selector.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				selector.Select(ui_widget.SelectorItem{Key: p.menu.Options[i].Key, Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = false
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+// load fills the value with the current target object on the server.
+func (p *schedulePage) load() {
+	v := task.Object(p.kind.Value(), strings.TrimSpace(p.target.Text()))
+	if v == nil {
+		p.target.SetError(i18n.ErrNotFound.Value())
+		return
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return
+	}
+	p.value.SetText(string(b))
+}
+
+func (p *schedulePage) save() bool {
+	s := &config.Schedule{
+		Name:     strings.TrimSpace(p.name.Text()),
+		Cron:     strings.TrimSpace(p.cron.Text()),
+		Action:   config.ScheduleAction(p.action.Value()),
+		Target:   strings.TrimSpace(p.target.Text()),
+		Disabled: !p.enabled.Value(),
+	}
+	if s.Action != config.ScheduleSave {
+		s.Kind = p.kind.Value()
+	}
+	if s.Action == config.ScheduleCreate || s.Action == config.ScheduleUpdate {
+		s.Value = strings.TrimSpace(p.value.Text())
+		if s.Value == "" {
+			s.Value = p.sealedValue
+		}
+	}
+
+	ok := true
+	if s.Name == "" {
+		p.name.SetError(i18n.ErrNameRequired.Value())
+		ok = false
+	}
+	if p.create {
+		if server := config.CurrentServer(); server != nil {
+			for _, v := range server.Schedules {
+				if v != nil && v.Name == s.Name {
+					p.name.SetError(i18n.ErrNameExists.Value())
+					ok = false
+					break
+				}
+			}
+		}
+	}
+	if _, err := runner.ParseCron(s.Cron); err != nil {
+		p.cron.SetError(err.Error())
+		ok = false
+	}
+	if s.Target == "" {
+		p.target.SetError(i18n.ErrNameRequired.Value())
+		ok = false
+	}
+	if s.Action == "" {
+		ok = false
+	}
+	if ok && s.Action != config.ScheduleSave {
+		// the schedule is checked before it is saved, instead of failing at its first run.
+		if _, err := task.ScheduleAction(s); err != nil {
+			p.value.SetError(err.Error())
+			ok = false
+		}
+	}
+	if !ok {
+		return false
+	}
+
+	if p.callback != nil {
+		if p.create {
+			p.callback(page.ActionCreate, s.Name, s)
+		} else {
+			p.callback(page.ActionUpdate, p.id, s)
+		}
+	}
+
+	return true
+}
+
+func (p *schedulePage) delete() {
+	if p.callback != nil {
+		p.callback(page.ActionDelete, p.id, nil)
+	}
+}
@@ -0,0 +1,249 @@
+package schedules
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api/runner"
+	"github.com/go-gost/gostctl/api/util"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+type schedule struct {
+	schedule *config.Schedule
+	clk      widget.Clickable
+}
+
+type schedulesPage struct {
+	readonly bool
+	router   *page.Router
+	list     widget.List
+
+	btnBack widget.Clickable
+	btnAdd  widget.Clickable
+
+	schedules []schedule
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &schedulesPage{
+		router: r,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
+		},
+	}
+}
+
+func (p *schedulesPage) Init(opts ...page.PageOption) {
+	p.readonly = false
+	p.schedules = nil
+
+	server := config.CurrentServer()
+	if server == nil {
+		return
+	}
+	p.readonly = server.IsReadonly()
+
+	for _, s := range server.Schedules {
+		if s == nil {
+			continue
+		}
+		p.schedules = append(p.schedules, schedule{schedule: s})
+	}
+}
+
+func (p *schedulesPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+	if p.btnAdd.Clicked(gtx) {
+		p.router.Goto(page.Route{
+			Path:     page.PageSchedule,
+			Callback: p.callback,
+			Perm:     page.PermReadWrite,
+		})
+	}
+
+	th := p.router.Theme
+
+	return layout.Stack{
+		Alignment: layout.SE,
+	}.Layout(gtx,
+		layout.Expanded(func(gtx page.C) page.D {
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx,
+				// header
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+						return layout.Flex{
+							Spacing:   layout.SpaceBetween,
+							Alignment: layout.Middle,
+						}.Layout(gtx,
+							layout.Rigid(func(gtx page.C) page.D {
+								btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+								btn.Color = th.Fg
+								btn.Background = th.Bg
+								return btn.Layout(gtx)
+							}),
+							layout.Rigid(layout.Spacer{Width: 8}.Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								title := material.H6(th, i18n.Schedules.Value())
+								return title.Layout(gtx)
+							}),
+							layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+						)
+					})
+				}),
+				layout.Flexed(1, func(gtx page.C) page.D {
+					return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+						return p.layout(gtx, th)
+					})
+				}),
+			)
+		}),
+		layout.Stacked(func(gtx page.C) page.D {
+			if p.readonly {
+				return page.D{}
+			}
+
+			return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+				btn := material.IconButton(th, &p.btnAdd, icons.IconAdd, "Add")
+				btn.Inset = layout.UniformInset(16)
+				return btn.Layout(gtx)
+			})
+		}),
+	)
+}
+
+func (p *schedulesPage) layout(gtx page.C, th *page.T) page.D {
+	for i := range p.schedules {
+		s := p.schedules[i].schedule
+		if p.schedules[i].clk.Clicked(gtx) {
+			perm := page.PermReadWriteDelete
+			if p.readonly {
+				perm = page.PermRead
+			}
+			v := *s
+			p.router.Goto(page.Route{
+				Path:     page.PageSchedule,
+				ID:       s.Name,
+				Value:    &v,
+				Callback: p.callback,
+				Perm:     perm,
+			})
+			break
+		}
+	}
+
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		if len(p.schedules) == 0 {
+			return layout.UniformInset(16).Layout(gtx, material.Body1(th, i18n.ScheduleNone.Value()).Layout)
+		}
+
+		return material.List(th, &p.list).Layout(gtx, len(p.schedules), func(gtx page.C, index int) page.D {
+			return p.layoutSchedule(gtx, th, &p.schedules[index])
+		})
+	})
+}
+
+func (p *schedulesPage) layoutSchedule(gtx page.C, th *page.T, s *schedule) page.D {
+	action := string(s.schedule.Action)
+	if s.schedule.Action == config.ScheduleSave {
+		action += " " + s.schedule.Target
+	} else {
+		action += fmt.Sprintf(" %s %s", s.schedule.Kind, s.schedule.Target)
+	}
+
+	next := ""
+	if cron, err := runner.ParseCron(s.schedule.Cron); err != nil {
+		next = err.Error()
+	} else if !s.schedule.Disabled {
+		if t := cron.Next(time.Now()); !t.IsZero() {
+			next = fmt.Sprintf("%s: %s", i18n.NextRun.Value(), t.Format(time.DateTime))
+		}
+	}
+
+	return material.Clickable(gtx, &s.clk, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx,
+				layout.Rigid(func(gtx page.C) page.D {
+					label := material.Body1(th, s.schedule.Name)
+					label.Font.Weight = font.SemiBold
+					if s.schedule.Disabled {
+						label.Color = color.NRGBA(colornames.Grey500)
+					}
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(layout.Spacer{Height: 4}.Layout),
+				layout.Rigid(material.Body2(th, fmt.Sprintf("%s  %s", s.schedule.Cron, action)).Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					if next == "" {
+						return page.D{}
+					}
+					return layout.Inset{Top: 4}.Layout(gtx, material.Caption(th, next).Layout)
+				}),
+			)
+		})
+	})
+}
+
+// callback applies the change of a schedule to the current server and restarts the schedules.
+func (p *schedulesPage) callback(action page.Action, id string, value any) {
+	server := config.CurrentServer()
+	if server == nil {
+		return
+	}
+
+	schedules := slices.Clone(server.Schedules)
+	switch action {
+	case page.ActionCreate:
+		s, _ := value.(*config.Schedule)
+		if s == nil {
+			return
+		}
+		schedules = append(schedules, s)
+	case page.ActionUpdate:
+		s, _ := value.(*config.Schedule)
+		if s == nil {
+			return
+		}
+		for i := range schedules {
+			if schedules[i] != nil && schedules[i].Name == id {
+				schedules[i] = s
+			}
+		}
+	case page.ActionDelete:
+		schedules = slices.DeleteFunc(schedules, func(s *config.Schedule) bool {
+			return s == nil || s.Name == id
+		})
+	}
+	server.Schedules = schedules
+
+	config.Get().Write()
+	util.RestartSchedules()
+
+	p.Init()
+}
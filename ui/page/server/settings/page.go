@@ -0,0 +1,883 @@
+package settings
+
+import (
+	"image/color"
+	"net"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+)
+
+type settingsPage struct {
+	readonly bool
+	router   *page.Router
+
+	menu ui_widget.Menu
+	list layout.List
+
+	btnBack   widget.Clickable
+	btnEdit   widget.Clickable
+	btnSave   widget.Clickable
+	btnConfig widget.Clickable
+
+	enableAPI     ui_widget.Switcher
+	apiAddr       component.TextField
+	apiPathPrefix component.TextField
+	apiAccessLog  ui_widget.Switcher
+	apiAuthType   widget.Enum
+	apiUsername   component.TextField
+	apiPassword   component.TextField
+	apiAuther     ui_widget.Selector
+
+	enableMetrics   ui_widget.Switcher
+	metricsAddr     component.TextField
+	metricsPath     component.TextField
+	metricsAuthType widget.Enum
+	metricsUsername component.TextField
+	metricsPassword component.TextField
+	metricsAuther   ui_widget.Selector
+
+	enableProfiling ui_widget.Switcher
+	profilingAddr   component.TextField
+
+	enableLog     ui_widget.Switcher
+	logOutput     component.TextField
+	logLevel      ui_widget.Selector
+	logFormat     ui_widget.Selector
+	logRotation   ui_widget.Switcher
+	logMaxSize    component.TextField
+	logMaxAge     component.TextField
+	logMaxBackups component.TextField
+	logLocalTime  ui_widget.Switcher
+	logCompress   ui_widget.Switcher
+
+	tls *page.TLSEditor
+
+	// API config loaded from the server, used to detect lockout-prone changes.
+	api *api.APIConfig
+
+	edit bool
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &settingsPage{
+		router: r,
+
+		list: layout.List{
+			// NOTE: the list must be vertical
+			Axis: layout.Vertical,
+		},
+
+		enableAPI: ui_widget.Switcher{Title: i18n.API},
+		apiAddr: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		apiPathPrefix: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		apiAccessLog: ui_widget.Switcher{Title: i18n.AccessLog},
+		apiUsername: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		apiPassword: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		apiAuther: ui_widget.Selector{Title: i18n.Auther},
+
+		enableMetrics: ui_widget.Switcher{Title: i18n.Metrics},
+		metricsAddr: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		metricsPath: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		metricsUsername: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		metricsPassword: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		metricsAuther: ui_widget.Selector{Title: i18n.Auther},
+
+		enableProfiling: ui_widget.Switcher{Title: i18n.Profiling},
+		profilingAddr: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+
+		enableLog: ui_widget.Switcher{Title: i18n.Log},
+		logOutput: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		logLevel:    ui_widget.Selector{Title: i18n.LogLevel},
+		logFormat:   ui_widget.Selector{Title: i18n.LogFormat},
+		logRotation: ui_widget.Switcher{Title: i18n.LogRotation},
+		logMaxSize: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     8,
+				Filter:     "1234567890",
+			},
+		},
+		logMaxAge: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     8,
+				Filter:     "1234567890",
+			},
+		},
+		logMaxBackups: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     8,
+				Filter:     "1234567890",
+			},
+		},
+		logLocalTime: ui_widget.Switcher{Title: i18n.LogLocalTime},
+		logCompress:  ui_widget.Switcher{Title: i18n.LogCompress},
+
+		tls: page.NewTLSEditor(r),
+	}
+}
+
+func (p *settingsPage) Init(opts ...page.PageOption) {
+	p.readonly = false
+	if server := config.CurrentServer(); server != nil {
+		p.readonly = server.IsReadonly()
+	}
+	p.edit = false
+
+	cfg := api.GetConfig()
+	p.api = cfg.API.Copy()
+
+	{
+		p.enableAPI.SetValue(false)
+		p.apiAddr.Clear()
+		p.apiPathPrefix.Clear()
+		p.apiAccessLog.SetValue(false)
+		p.initAuth(nil, "", &p.apiAuthType, &p.apiUsername, &p.apiPassword, &p.apiAuther)
+
+		if c := cfg.API; c != nil {
+			p.enableAPI.SetValue(true)
+			p.apiAddr.SetText(c.Addr)
+			p.apiPathPrefix.SetText(c.PathPrefix)
+			p.apiAccessLog.SetValue(c.AccessLog)
+			p.initAuth(c.Auth, c.Auther, &p.apiAuthType, &p.apiUsername, &p.apiPassword, &p.apiAuther)
+		}
+	}
+
+	{
+		p.enableMetrics.SetValue(false)
+		p.metricsAddr.Clear()
+		p.metricsPath.Clear()
+		p.initAuth(nil, "", &p.metricsAuthType, &p.metricsUsername, &p.metricsPassword, &p.metricsAuther)
+
+		if c := cfg.Metrics; c != nil {
+			p.enableMetrics.SetValue(true)
+			p.metricsAddr.SetText(c.Addr)
+			p.metricsPath.SetText(c.Path)
+			p.initAuth(c.Auth, c.Auther, &p.metricsAuthType, &p.metricsUsername, &p.metricsPassword, &p.metricsAuther)
+		}
+	}
+
+	{
+		p.enableProfiling.SetValue(false)
+		p.profilingAddr.Clear()
+
+		if c := cfg.Profiling; c != nil {
+			p.enableProfiling.SetValue(true)
+			p.profilingAddr.SetText(c.Addr)
+		}
+	}
+
+	{
+		p.enableLog.SetValue(false)
+		p.logOutput.Clear()
+		p.logLevel.Clear()
+		p.logFormat.Clear()
+		p.logRotation.SetValue(false)
+		p.logMaxSize.Clear()
+		p.logMaxAge.Clear()
+		p.logMaxBackups.Clear()
+		p.logLocalTime.SetValue(false)
+		p.logCompress.SetValue(false)
+
+		if c := cfg.Log; c != nil {
+			p.enableLog.SetValue(true)
+			p.logOutput.SetText(c.Output)
+			for i := range logLevelOptions {
+				if logLevelOptions[i].Value == c.Level {
+					p.logLevel.Select(ui_widget.SelectorItem{Name: logLevelOptions[i].Name, Value: logLevelOptions[i].Value})
+					break
+				}
+			}
+			for i := range logFormatOptions {
+				if logFormatOptions[i].Value == c.Format {
+					p.logFormat.Select(ui_widget.SelectorItem{Name: logFormatOptions[i].Name, Value: logFormatOptions[i].Value})
+					break
+				}
+			}
+			if rotation := c.Rotation; rotation != nil {
+				p.logRotation.SetValue(true)
+				p.logMaxSize.SetText(strconv.Itoa(rotation.MaxSize))
+				p.logMaxAge.SetText(strconv.Itoa(rotation.MaxAge))
+				p.logMaxBackups.SetText(strconv.Itoa(rotation.MaxBackups))
+				p.logLocalTime.SetValue(rotation.LocalTime)
+				p.logCompress.SetValue(rotation.Compress)
+			}
+		}
+	}
+
+	p.tls.Init(cfg.TLS)
+}
+
+func (p *settingsPage) initAuth(auth *api.AuthConfig, auther string, authType *widget.Enum, username, password *component.TextField, autherSelector *ui_widget.Selector) {
+	authType.Value = ""
+	username.Clear()
+	password.Clear()
+	autherSelector.Clear()
+
+	if auth != nil {
+		authType.Value = string(page.AuthSimple)
+		username.SetText(auth.Username)
+		password.SetText(auth.Password)
+	}
+	if auther != "" {
+		authType.Value = string(page.AuthAuther)
+		autherSelector.Select(ui_widget.SelectorItem{Value: auther})
+	}
+}
+
+func (p *settingsPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+	if p.btnEdit.Clicked(gtx) {
+		p.edit = true
+	}
+	if p.btnSave.Clicked(gtx) && p.validate() {
+		p.save()
+	}
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Flexed(1, func(gtx page.C) page.D {
+						title := material.H6(th, i18n.ServerSettings.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if p.readonly {
+							return page.D{}
+						}
+
+						if p.edit {
+							btn := material.IconButton(th, &p.btnSave, icons.IconDone, "Show config")
+							btn.Color = th.Fg
+							btn.Background = th.Bg
+							return btn.Layout(gtx)
+						} else {
+							btn := material.IconButton(th, &p.btnEdit, icons.IconEdit, "Edit")
+							btn.Color = th.Fg
+							btn.Background = th.Bg
+							return btn.Layout(gtx)
+						}
+					}),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return p.list.Layout(gtx, 1, func(gtx page.C, index int) page.D {
+				return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+					return p.layout(gtx, th)
+				})
+			})
+		}),
+	)
+}
+
+func (p *settingsPage) layout(gtx page.C, th *page.T) page.D {
+	if p.btnConfig.Clicked(gtx) {
+		p.router.Goto(page.Route{
+			Path:  page.PageConfig,
+			Value: p.generateConfig(),
+		})
+	}
+
+	src := gtx.Source
+
+	if !p.edit {
+		gtx = gtx.Disabled()
+	}
+
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx,
+				layout.Rigid(func(gtx page.C) page.D {
+					gtx.Source = src
+
+					return layout.Flex{
+						Alignment: layout.Middle,
+					}.Layout(gtx,
+						layout.Flexed(1, material.Body2(th, i18n.ServerSettingsHint.Value()).Layout),
+						layout.Rigid(layout.Spacer{Width: 8}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							btn := material.IconButton(th, &p.btnConfig, icons.IconCode, "Config")
+							btn.Color = th.Fg
+							btn.Background = theme.Current().ContentSurfaceBg
+							return btn.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(layout.Spacer{Height: 16}.Layout),
+
+				// API
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.enableAPI.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					if !p.enableAPI.Value() {
+						return page.D{}
+					}
+
+					return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+						return layout.Flex{
+							Axis: layout.Vertical,
+						}.Layout(gtx,
+							layout.Rigid(material.Body1(th, i18n.Address.Value()).Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.apiAddr.Layout(gtx, th, "")
+							}),
+							layout.Rigid(layout.Spacer{Height: 8}.Layout),
+							layout.Rigid(material.Body1(th, i18n.PathPrefix.Value()).Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.apiPathPrefix.Layout(gtx, th, "")
+							}),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.apiAccessLog.Layout(gtx, th)
+							}),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.layoutAuth(gtx, th, &p.apiAuthType, &p.apiUsername, &p.apiPassword, &p.apiAuther)
+							}),
+						)
+					})
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					if !p.lockout() {
+						return page.D{}
+					}
+
+					return layout.Inset{
+						Top:    4,
+						Bottom: 8,
+					}.Layout(gtx, func(gtx page.C) page.D {
+						return layout.Flex{
+							Alignment: layout.Middle,
+						}.Layout(gtx,
+							layout.Rigid(func(gtx page.C) page.D {
+								gtx.Constraints.Min.X = gtx.Dp(20)
+								return icons.IconAlert.Layout(gtx, color.NRGBA(colornames.Red500))
+							}),
+							layout.Rigid(layout.Spacer{Width: 8}.Layout),
+							layout.Flexed(1, func(gtx page.C) page.D {
+								label := material.Body2(th, i18n.WarnAPILockout.Value())
+								label.Color = color.NRGBA(colornames.Red500)
+								return label.Layout(gtx)
+							}),
+						)
+					})
+				}),
+
+				// Metrics
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.enableMetrics.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					if !p.enableMetrics.Value() {
+						return page.D{}
+					}
+
+					return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+						return layout.Flex{
+							Axis: layout.Vertical,
+						}.Layout(gtx,
+							layout.Rigid(material.Body1(th, i18n.Address.Value()).Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.metricsAddr.Layout(gtx, th, "")
+							}),
+							layout.Rigid(layout.Spacer{Height: 8}.Layout),
+							layout.Rigid(material.Body1(th, i18n.Path.Value()).Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.metricsPath.Layout(gtx, th, "")
+							}),
+							layout.Rigid(layout.Spacer{Height: 8}.Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.layoutAuth(gtx, th, &p.metricsAuthType, &p.metricsUsername, &p.metricsPassword, &p.metricsAuther)
+							}),
+						)
+					})
+				}),
+
+				// Profiling
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.enableProfiling.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					if !p.enableProfiling.Value() {
+						return page.D{}
+					}
+
+					return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+						return layout.Flex{
+							Axis: layout.Vertical,
+						}.Layout(gtx,
+							layout.Rigid(material.Body1(th, i18n.Address.Value()).Layout),
+							layout.Rigid(func(gtx page.C) page.D {
+								return p.profilingAddr.Layout(gtx, th, "")
+							}),
+						)
+					})
+				}),
+
+				// Log
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.enableLog.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					if !p.enableLog.Value() {
+						return page.D{}
+					}
+
+					return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+						return p.layoutLog(gtx, th)
+					})
+				}),
+
+				// TLS
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.tls.Layout(gtx, th)
+				}),
+			)
+		})
+	})
+}
+
+func (p *settingsPage) layoutAuth(gtx page.C, th *page.T, authType *widget.Enum, username, password *component.TextField, auther *ui_widget.Selector) page.D {
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.Inset{
+				Top:    4,
+				Bottom: 4,
+			}.Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Alignment: layout.Middle,
+					Spacing:   layout.SpaceBetween,
+				}.Layout(gtx,
+					layout.Flexed(1, func(gtx page.C) page.D {
+						return material.Body1(th, i18n.Auth.Value()).Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						return material.RadioButton(th, authType, string(page.AuthSimple), i18n.AuthSimple.Value()).Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						return material.RadioButton(th, authType, string(page.AuthAuther), i18n.AuthAuther.Value()).Layout(gtx)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if authType.Value != string(page.AuthSimple) {
+				return page.D{}
+			}
+
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Axis: layout.Vertical,
+				}.Layout(gtx,
+					layout.Rigid(material.Body1(th, i18n.Username.Value()).Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						return username.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+					layout.Rigid(material.Body1(th, i18n.Password.Value()).Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						return password.Layout(gtx, th, "")
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if authType.Value != string(page.AuthAuther) {
+				return page.D{}
+			}
+
+			if auther.Clicked(gtx) {
+				p.showAutherMenu(gtx, auther)
+			}
+
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return auther.Layout(gtx, th)
+			})
+		}),
+	)
+}
+
+func (p *settingsPage) layoutLog(gtx page.C, th *page.T) page.D {
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.Flex{
+				Alignment: layout.Baseline,
+			}.Layout(gtx,
+				layout.Rigid(material.Body1(th, i18n.LogOutput.Value()).Layout),
+				layout.Rigid(layout.Spacer{Width: 4}.Layout),
+				layout.Rigid(material.Body2(th, "("+i18n.LogOutputHint.Value()+")").Layout),
+			)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.logOutput.Layout(gtx, th, "")
+		}),
+		layout.Rigid(layout.Spacer{Height: 4}.Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			if p.logLevel.Clicked(gtx) {
+				p.showOptionMenu(gtx, i18n.LogLevel, logLevelOptions, &p.logLevel)
+			}
+			return p.logLevel.Layout(gtx, th)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if p.logFormat.Clicked(gtx) {
+				p.showOptionMenu(gtx, i18n.LogFormat, logFormatOptions, &p.logFormat)
+			}
+			return p.logFormat.Layout(gtx, th)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.logRotation.Layout(gtx, th)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if !p.logRotation.Value() {
+				return page.D{}
+			}
+
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Axis: layout.Vertical,
+				}.Layout(gtx,
+					layout.Rigid(material.Body1(th, i18n.LogMaxSize.Value()).Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.logMaxSize.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+					layout.Rigid(material.Body1(th, i18n.LogMaxAge.Value()).Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.logMaxAge.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+					layout.Rigid(material.Body1(th, i18n.LogMaxBackups.Value()).Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.logMaxBackups.Layout(gtx, th, "")
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.logLocalTime.Layout(gtx, th)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.logCompress.Layout(gtx, th)
+					}),
+				)
+			})
+		}),
+	)
+}
+
+var (
+	logLevelOptions = []ui_widget.MenuOption{
+		{Name: "trace", Value: "trace"},
+		{Name: "debug", Value: "debug"},
+		{Name: "info", Value: "info"},
+		{Name: "warn", Value: "warn"},
+		{Name: "error", Value: "error"},
+		{Name: "fatal", Value: "fatal"},
+	}
+	logFormatOptions = []ui_widget.MenuOption{
+		{Name: "JSON", Value: "json"},
+		{Name: "Text", Value: "text"},
+	}
+)
+
+func (p *settingsPage) showOptionMenu(gtx page.C, title i18n.Key, options []ui_widget.MenuOption, selector *ui_widget.Selector) {
+	for i := range options {
+		options[i].Selected = selector.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = title
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		selector.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				selector.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Key: p.menu.Options[i].Key, Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = false
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+func (p *settingsPage) showAutherMenu(gtx page.C, selector *ui_widget.Selector) {
+	options := []ui_widget.MenuOption{}
+	for _, v := range api.GetConfig().Authers {
+		options = append(options, ui_widget.MenuOption{
+			Value: v.Name,
+		})
+	}
+	for i := range options {
+		options[i].Selected = selector.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = i18n.Auther
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		selector.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				selector.Select(ui_widget.SelectorItem{Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = func() {
+		p.router.Goto(page.Route{
+			Path: page.PageAuther,
+			Perm: page.PermReadWrite,
+		})
+		p.router.HideModal(gtx)
+	}
+	p.menu.Multiple = false
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+// lockout reports whether the edited API settings differ from the ones
+// gostctl is currently connected through.
+func (p *settingsPage) lockout() bool {
+	if p.api == nil {
+		return false
+	}
+
+	cfg := p.generateAPIConfig()
+	if cfg == nil {
+		return true
+	}
+
+	if cfg.Addr != p.api.Addr ||
+		cfg.PathPrefix != p.api.PathPrefix ||
+		cfg.Auther != p.api.Auther {
+		return true
+	}
+
+	if (cfg.Auth == nil) != (p.api.Auth == nil) {
+		return true
+	}
+	if cfg.Auth != nil && *cfg.Auth != *p.api.Auth {
+		return true
+	}
+
+	return false
+}
+
+// validate checks the edited settings, the errors are shown along with the fields.
+func (p *settingsPage) validate() bool {
+	valid := true
+	check := func(enabled bool, addr *component.TextField) {
+		addr.ClearError()
+		if !enabled {
+			return
+		}
+
+		v := strings.TrimSpace(addr.Text())
+		if v == "" {
+			addr.SetError(i18n.ErrAddrRequired.Value())
+			valid = false
+			return
+		}
+		if _, _, err := net.SplitHostPort(v); err != nil {
+			addr.SetError(i18n.ErrInvalidAddr.Value())
+			valid = false
+		}
+	}
+
+	check(p.enableAPI.Value(), &p.apiAddr)
+	check(p.enableMetrics.Value(), &p.metricsAddr)
+	check(p.enableProfiling.Value(), &p.profilingAddr)
+
+	return valid
+}
+
+func (p *settingsPage) save() {
+	p.edit = false
+
+	p.router.Goto(page.Route{
+		Path:  page.PageConfig,
+		Value: p.generateConfig(),
+	})
+}
+
+func (p *settingsPage) generateConfig() *api.Config {
+	cfg := api.GetConfig().Copy()
+
+	cfg.API = p.generateAPIConfig()
+
+	cfg.Metrics = nil
+	if p.enableMetrics.Value() {
+		cfg.Metrics = &api.MetricsConfig{
+			Addr: strings.TrimSpace(p.metricsAddr.Text()),
+			Path: strings.TrimSpace(p.metricsPath.Text()),
+		}
+		cfg.Metrics.Auth, cfg.Metrics.Auther = p.generateAuth(&p.metricsAuthType, &p.metricsUsername, &p.metricsPassword, &p.metricsAuther)
+	}
+
+	cfg.Profiling = nil
+	if p.enableProfiling.Value() {
+		cfg.Profiling = &api.ProfilingConfig{
+			Addr: strings.TrimSpace(p.profilingAddr.Text()),
+		}
+	}
+
+	cfg.Log = nil
+	if p.enableLog.Value() {
+		cfg.Log = &api.LogConfig{
+			Output: strings.TrimSpace(p.logOutput.Text()),
+			Level:  p.logLevel.Value(),
+			Format: p.logFormat.Value(),
+		}
+		if p.logRotation.Value() {
+			maxSize, _ := strconv.Atoi(p.logMaxSize.Text())
+			maxAge, _ := strconv.Atoi(p.logMaxAge.Text())
+			maxBackups, _ := strconv.Atoi(p.logMaxBackups.Text())
+			cfg.Log.Rotation = &api.LogRotationConfig{
+				MaxSize:    maxSize,
+				MaxAge:     maxAge,
+				MaxBackups: maxBackups,
+				LocalTime:  p.logLocalTime.Value(),
+				Compress:   p.logCompress.Value(),
+			}
+		}
+	}
+
+	cfg.TLS = p.tls.Config()
+
+	return cfg
+}
+
+func (p *settingsPage) generateAPIConfig() *api.APIConfig {
+	if !p.enableAPI.Value() {
+		return nil
+	}
+
+	cfg := &api.APIConfig{
+		Addr:       strings.TrimSpace(p.apiAddr.Text()),
+		PathPrefix: strings.TrimSpace(p.apiPathPrefix.Text()),
+		AccessLog:  p.apiAccessLog.Value(),
+	}
+	cfg.Auth, cfg.Auther = p.generateAuth(&p.apiAuthType, &p.apiUsername, &p.apiPassword, &p.apiAuther)
+
+	return cfg
+}
+
+func (p *settingsPage) generateAuth(authType *widget.Enum, username, password *component.TextField, auther *ui_widget.Selector) (*api.AuthConfig, string) {
+	switch authType.Value {
+	case string(page.AuthSimple):
+		if u := strings.TrimSpace(username.Text()); u != "" {
+			return &api.AuthConfig{
+				Username: u,
+				Password: strings.TrimSpace(password.Text()),
+			}, ""
+		}
+	case string(page.AuthAuther):
+		return nil, auther.Value()
+	}
+	return nil, ""
+}
@@ -0,0 +1,441 @@
+package transfer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/config"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+)
+
+const exportFile = "servers.yml"
+
+type transferPage struct {
+	router *page.Router
+	menu   ui_widget.Menu
+	list   widget.List
+
+	btnBack widget.Clickable
+
+	tags         ui_widget.Selector
+	servers      ui_widget.Selector
+	stripSecrets ui_widget.Switcher
+	exportFile   component.TextField
+	btnCopyLink  widget.Clickable
+	btnExport    widget.Clickable
+
+	importFile component.TextField
+	// importLocal imports the settings referring to the local files of the exporting system.
+	importLocal ui_widget.Switcher
+	btnImport   widget.Clickable
+
+	link   component.TextField
+	btnAdd widget.Clickable
+}
+
+func NewPage(r *page.Router) page.Page {
+	return &transferPage{
+		router: r,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
+		},
+		tags:         ui_widget.Selector{Title: i18n.Tags},
+		servers:      ui_widget.Selector{Title: i18n.Servers},
+		stripSecrets: ui_widget.Switcher{Title: i18n.StripSecrets},
+		importLocal:  ui_widget.Switcher{Title: i18n.ImportLocalSettings},
+		exportFile: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		importFile: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		link: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     1024,
+			},
+		},
+	}
+}
+
+func (p *transferPage) Init(opts ...page.PageOption) {
+	p.tags.Clear()
+	p.servers.Clear()
+	for _, server := range config.Get().Servers {
+		p.servers.Select(ui_widget.SelectorItem{Name: server.Name, Value: server.Name})
+	}
+	p.stripSecrets.SetValue(true)
+
+	file := filepath.Join(config.Dir(), exportFile)
+	p.exportFile.Clear()
+	p.exportFile.SetText(file)
+	p.importFile.Clear()
+	p.importFile.SetText(file)
+	p.importLocal.SetValue(false)
+
+	p.link.Clear()
+}
+
+func (p *transferPage) Layout(gtx page.C) page.D {
+	if p.btnBack.Clicked(gtx) {
+		p.router.Back()
+	}
+
+	th := p.router.Theme
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		// header
+		layout.Rigid(func(gtx page.C) page.D {
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Spacing:   layout.SpaceBetween,
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						btn := material.IconButton(th, &p.btnBack, icons.IconBack, "Back")
+						btn.Color = th.Fg
+						btn.Background = th.Bg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(func(gtx page.C) page.D {
+						title := material.H6(th, i18n.ServerTransfer.Value())
+						return title.Layout(gtx)
+					}),
+					layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx page.C) page.D {
+			return p.list.Layout(gtx, 1, func(gtx page.C, _ int) page.D {
+				return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+					return p.layout(gtx, th)
+				})
+			})
+		}),
+	)
+}
+
+func (p *transferPage) layout(gtx page.C, th *page.T) page.D {
+	if p.tags.Clicked(gtx) {
+		p.showTagsMenu(gtx)
+	}
+	if p.servers.Clicked(gtx) {
+		p.showServersMenu(gtx)
+	}
+	if p.btnCopyLink.Clicked(gtx) {
+		p.copyLinks(gtx)
+	}
+	if p.btnExport.Clicked(gtx) {
+		p.export()
+	}
+	if p.btnImport.Clicked(gtx) {
+		p.importServers()
+	}
+	if p.btnAdd.Clicked(gtx) {
+		p.addServer()
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.layoutSection(gtx, th, i18n.Export,
+				layout.Rigid(func(gtx page.C) page.D {
+					if len(config.ServerTags()) == 0 {
+						return page.D{}
+					}
+					return p.tags.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.servers.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.stripSecrets.Layout(gtx, th)
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+				layout.Rigid(material.Body1(th, i18n.FilePath.Value()).Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.exportFile.Layout(gtx, th, "")
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.Flex{
+						Alignment: layout.Middle,
+					}.Layout(gtx,
+						layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							return layoutButton(gtx, th, &p.btnCopyLink, i18n.CopyLink)
+						}),
+						layout.Rigid(layout.Spacer{Width: 8}.Layout),
+						layout.Rigid(func(gtx page.C) page.D {
+							return layoutButton(gtx, th, &p.btnExport, i18n.Export)
+						}),
+					)
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: 16}.Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.layoutSection(gtx, th, i18n.Import,
+				layout.Rigid(material.Body1(th, i18n.FilePath.Value()).Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.importFile.Layout(gtx, th, "")
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.importLocal.Layout(gtx, th)
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.E.Layout(gtx, func(gtx page.C) page.D {
+						return layoutButton(gtx, th, &p.btnImport, i18n.Import)
+					})
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: 16}.Layout),
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.layoutSection(gtx, th, i18n.ServerLink,
+				layout.Rigid(func(gtx page.C) page.D {
+					return p.link.Layout(gtx, th, i18n.ServerLinkHint.Value())
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.E.Layout(gtx, func(gtx page.C) page.D {
+						return layoutButton(gtx, th, &p.btnAdd, i18n.AddServer)
+					})
+				}),
+			)
+		}),
+	)
+}
+
+func (p *transferPage) layoutSection(gtx page.C, th *page.T, title i18n.Key, children ...layout.FlexChild) page.D {
+	return component.SurfaceStyle{
+		Theme: th,
+		ShadowStyle: component.ShadowStyle{
+			CornerRadius: 12,
+		},
+		Fill: theme.Current().ContentSurfaceBg,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.UniformInset(16).Layout(gtx, func(gtx page.C) page.D {
+			children = append([]layout.FlexChild{
+				layout.Rigid(func(gtx page.C) page.D {
+					label := material.Body1(th, title.Value())
+					label.Font.Weight = font.SemiBold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(layout.Spacer{Height: 8}.Layout),
+			}, children...)
+
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx, children...)
+		})
+	})
+}
+
+func layoutButton(gtx page.C, th *page.T, btn *widget.Clickable, text i18n.Key) page.D {
+	return material.ButtonLayoutStyle{
+		Background:   th.Bg,
+		CornerRadius: 18,
+		Button:       btn,
+	}.Layout(gtx, func(gtx page.C) page.D {
+		return layout.Inset{
+			Top:    8,
+			Bottom: 8,
+			Left:   20,
+			Right:  20,
+		}.Layout(gtx, func(gtx page.C) page.D {
+			label := material.Body1(th, text.Value())
+			label.Color = th.Fg
+			return label.Layout(gtx)
+		})
+	})
+}
+
+func (p *transferPage) showServersMenu(gtx page.C) {
+	var options []ui_widget.MenuOption
+	for _, server := range config.Get().Servers {
+		options = append(options, ui_widget.MenuOption{
+			Name:     server.Name,
+			Value:    server.Name,
+			Selected: p.servers.AnyValue(server.Name),
+		})
+	}
+
+	p.menu.Title = i18n.Servers
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		p.servers.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				p.servers.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = true
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+// showTagsMenu selects the servers by tags.
+func (p *transferPage) showTagsMenu(gtx page.C) {
+	var options []ui_widget.MenuOption
+	for _, tag := range config.ServerTags() {
+		options = append(options, ui_widget.MenuOption{
+			Name:     tag,
+			Value:    tag,
+			Selected: p.tags.AnyValue(tag),
+		})
+	}
+
+	p.menu.Title = i18n.Tags
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		p.tags.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				p.tags.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Value: p.menu.Options[i].Value})
+			}
+		}
+		if tags := p.tags.Values(); len(tags) > 0 {
+			p.servers.Clear()
+			for _, server := range config.ServersByTag(tags...) {
+				p.servers.Select(ui_widget.SelectorItem{Name: server.Name, Value: server.Name})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = true
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+// copyLinks copies the links of the selected servers to the clipboard, one per line.
+func (p *transferPage) copyLinks(gtx page.C) {
+	var links []string
+	for _, server := range config.Get().Servers {
+		if p.servers.AnyValue(server.Name) {
+			links = append(links, config.ServerLink(server))
+		}
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	gtx.Execute(clipboard.WriteCmd{
+		Data: io.NopCloser(bytes.NewBufferString(strings.Join(links, "\n"))),
+	})
+	p.router.Notify(ui_widget.Message{
+		Type:    ui_widget.Success,
+		Content: i18n.LinkCopied.Value(),
+	})
+}
+
+func (p *transferPage) export() {
+	names := p.servers.Values()
+	if len(names) == 0 {
+		p.exportFile.SetError(config.ErrNoServers.Error())
+		return
+	}
+
+	file := strings.TrimSpace(p.exportFile.Text())
+	if file == "" {
+		p.exportFile.SetError(i18n.ErrFilePathRequired.Value())
+		return
+	}
+
+	if err := config.ExportServers(file, names, p.stripSecrets.Value()); err != nil {
+		p.exportFile.SetError(err.Error())
+		return
+	}
+	p.exportFile.ClearError()
+
+	p.router.Notify(ui_widget.Message{
+		Type:    ui_widget.Success,
+		Content: fmt.Sprintf("%s: %s", i18n.ServersExported.Value(), file),
+	})
+}
+
+func (p *transferPage) importServers() {
+	file := strings.TrimSpace(p.importFile.Text())
+	if file == "" {
+		p.importFile.SetError(i18n.ErrFilePathRequired.Value())
+		return
+	}
+
+	names, noPassword, err := config.ImportServers(file, p.importLocal.Value())
+	if err != nil {
+		p.importFile.SetError(err.Error())
+		return
+	}
+	p.importFile.ClearError()
+
+	if len(noPassword) > 0 {
+		p.router.Notify(ui_widget.Message{
+			Type:    ui_widget.Warn,
+			Content: fmt.Sprintf("%s: %s", i18n.PasswordReenter.Value(), strings.Join(noPassword, ", ")),
+		})
+	} else {
+		p.router.Notify(ui_widget.Message{
+			Type:    ui_widget.Success,
+			Content: fmt.Sprintf("%s: %s", i18n.ServersImported.Value(), strings.Join(names, ", ")),
+		})
+	}
+	p.Init()
+}
+
+// addServer opens the server page filled with the server of the link, to be reviewed before it is added.
+func (p *transferPage) addServer() {
+	server, err := config.ParseServerLink(p.link.Text())
+	if err != nil {
+		p.link.SetError(err.Error())
+		return
+	}
+	p.link.ClearError()
+
+	p.router.Goto(page.Route{
+		Path:  page.PageServer,
+		Value: server,
+		Perm:  page.PermReadWrite,
+	})
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/ui/i18n"
+	"github.com/go-gost/gostctl/ui/icons"
+	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+)
+
+type chainGroupItem struct {
+	name   string
+	up     widget.Clickable
+	down   widget.Clickable
+	delete widget.Clickable
+}
+
+// chainGroup edits the chain group of a handler or listener.
+type chainGroup struct {
+	service *servicePage
+	menu    ui_widget.Menu
+
+	enable        ui_widget.Switcher
+	chainSelector ui_widget.Selector
+	chains        []*chainGroupItem
+
+	enableSelector      ui_widget.Switcher
+	selectorStrategy    ui_widget.Selector
+	selectorMaxFails    component.TextField
+	selectorFailTimeout component.TextField
+}
+
+func newChainGroup(service *servicePage) *chainGroup {
+	return &chainGroup{
+		service:       service,
+		enable:        ui_widget.Switcher{Title: i18n.ChainGroup},
+		chainSelector: ui_widget.Selector{Title: i18n.Chains},
+
+		enableSelector:   ui_widget.Switcher{Title: i18n.Selector},
+		selectorStrategy: ui_widget.Selector{Title: i18n.SelectorStrategy},
+		selectorMaxFails: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     8,
+				Filter:     "1234567890",
+			},
+		},
+		selectorFailTimeout: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     16,
+				Filter:     "1234567890",
+			},
+			Suffix: func(gtx page.C) page.D {
+				return material.Body1(service.router.Theme, i18n.TimeSecond.Value()).Layout(gtx)
+			},
+		},
+	}
+}
+
+func (p *chainGroup) init(cfg *api.ChainGroupConfig) {
+	p.enable.SetValue(false)
+	p.chains = nil
+	p.enableSelector.SetValue(false)
+	p.selectorStrategy.Clear()
+	p.selectorMaxFails.Clear()
+	p.selectorFailTimeout.Clear()
+
+	if cfg != nil {
+		p.enable.SetValue(true)
+		for _, name := range cfg.Chains {
+			if name == "" {
+				continue
+			}
+			p.chains = append(p.chains, &chainGroupItem{name: name})
+		}
+
+		if selector := cfg.Selector; selector != nil {
+			p.enableSelector.SetValue(true)
+			for i := range page.SelectorStrategyOptions {
+				if page.SelectorStrategyOptions[i].Value == selector.Strategy {
+					p.selectorStrategy.Select(ui_widget.SelectorItem{Name: page.SelectorStrategyOptions[i].Name, Key: page.SelectorStrategyOptions[i].Key, Value: page.SelectorStrategyOptions[i].Value})
+					break
+				}
+			}
+			p.selectorMaxFails.SetText(strconv.Itoa(selector.MaxFails))
+			p.selectorFailTimeout.SetText(strconv.Itoa(int(selector.FailTimeout.Seconds())))
+		}
+	}
+
+	p.updateChainSelector()
+}
+
+func (p *chainGroup) updateChainSelector() {
+	p.chainSelector.Clear()
+	for _, item := range p.chains {
+		p.chainSelector.Select(ui_widget.SelectorItem{Value: item.name})
+	}
+}
+
+// Enabled reports whether the chain group is used instead of a single chain.
+func (p *chainGroup) Enabled() bool {
+	return p.enable.Value()
+}
+
+func (p *chainGroup) generateConfig() *api.ChainGroupConfig {
+	if !p.enable.Value() || len(p.chains) == 0 {
+		return nil
+	}
+
+	cfg := &api.ChainGroupConfig{}
+	for _, item := range p.chains {
+		cfg.Chains = append(cfg.Chains, item.name)
+	}
+
+	if p.enableSelector.Value() {
+		maxFails, _ := strconv.Atoi(p.selectorMaxFails.Text())
+		failTimeout, _ := strconv.Atoi(p.selectorFailTimeout.Text())
+		cfg.Selector = &api.SelectorConfig{
+			Strategy:    p.selectorStrategy.Value(),
+			MaxFails:    maxFails,
+			FailTimeout: time.Duration(failTimeout) * time.Second,
+		}
+	}
+
+	return cfg
+}
+
+func (p *chainGroup) Layout(gtx page.C, th *page.T) page.D {
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx page.C) page.D {
+			return p.enable.Layout(gtx, th)
+		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if !p.enable.Value() {
+				return page.D{}
+			}
+
+			return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+				return layout.Flex{
+					Axis: layout.Vertical,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx page.C) page.D {
+						if p.chainSelector.Clicked(gtx) {
+							p.showChainMenu(gtx)
+						}
+						return p.chainSelector.Layout(gtx, th)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.layoutChains(gtx, th)
+					}),
+
+					// Selector
+					layout.Rigid(func(gtx page.C) page.D {
+						return p.enableSelector.Layout(gtx, th)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if !p.enableSelector.Value() {
+							return page.D{}
+						}
+
+						return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
+							return layout.Flex{
+								Axis: layout.Vertical,
+							}.Layout(gtx,
+								layout.Rigid(func(gtx page.C) page.D {
+									if p.selectorStrategy.Clicked(gtx) {
+										p.showSelectorStrategyMenu(gtx)
+									}
+									return p.selectorStrategy.Layout(gtx, th)
+								}),
+								layout.Rigid(layout.Spacer{Height: 4}.Layout),
+								layout.Rigid(material.Body1(th, i18n.SelectorMaxFails.Value()).Layout),
+								layout.Rigid(func(gtx page.C) page.D {
+									return p.selectorMaxFails.Layout(gtx, th, "")
+								}),
+								layout.Rigid(layout.Spacer{Height: 8}.Layout),
+								layout.Rigid(material.Body1(th, i18n.SelectorFailTimeout.Value()).Layout),
+								layout.Rigid(func(gtx page.C) page.D {
+									return p.selectorFailTimeout.Layout(gtx, th, "")
+								}),
+							)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
+func (p *chainGroup) layoutChains(gtx page.C, th *page.T) page.D {
+	for i, item := range p.chains {
+		if item.up.Clicked(gtx) && i > 0 {
+			p.chains[i-1], p.chains[i] = p.chains[i], p.chains[i-1]
+			p.updateChainSelector()
+			break
+		}
+		if item.down.Clicked(gtx) && i < len(p.chains)-1 {
+			p.chains[i], p.chains[i+1] = p.chains[i+1], p.chains[i]
+			p.updateChainSelector()
+			break
+		}
+		if item.delete.Clicked(gtx) {
+			p.chains = append(p.chains[:i], p.chains[i+1:]...)
+			p.updateChainSelector()
+			break
+		}
+	}
+
+	var children []layout.FlexChild
+	for i := range p.chains {
+		item := p.chains[i]
+
+		children = append(children,
+			layout.Rigid(func(gtx page.C) page.D {
+				return layout.Flex{
+					Alignment: layout.Middle,
+				}.Layout(gtx,
+					layout.Flexed(1, func(gtx page.C) page.D {
+						return layout.UniformInset(8).Layout(gtx, material.Body2(th, strconv.Itoa(i+1)+". "+item.name).Layout)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if !p.service.edit {
+							return page.D{}
+						}
+						btn := material.IconButton(th, &item.up, icons.IconNavExpandLess, "up")
+						btn.Background = theme.Current().ContentSurfaceBg
+						btn.Color = th.Fg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if !p.service.edit {
+							return page.D{}
+						}
+						btn := material.IconButton(th, &item.down, icons.IconNavExpandMore, "down")
+						btn.Background = theme.Current().ContentSurfaceBg
+						btn.Color = th.Fg
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx page.C) page.D {
+						if !p.service.edit {
+							return page.D{}
+						}
+						btn := material.IconButton(th, &item.delete, icons.IconDelete, "delete")
+						btn.Background = theme.Current().ContentSurfaceBg
+						btn.Color = th.Fg
+						return btn.Layout(gtx)
+					}),
+				)
+			}),
+		)
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx, children...)
+}
+
+func (p *chainGroup) showChainMenu(gtx page.C) {
+	options := []ui_widget.MenuOption{}
+	for _, v := range api.GetConfig().Chains {
+		options = append(options, ui_widget.MenuOption{
+			Value: v.Name,
+		})
+	}
+	for i := range options {
+		options[i].Selected = p.chainSelector.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = i18n.Chains
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.service.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		selected := map[string]bool{}
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				selected[p.menu.Options[i].Value] = true
+			}
+		}
+
+		// keep the order of the chains already in the group, append the new ones.
+		var chains []*chainGroupItem
+		for _, item := range p.chains {
+			if selected[item.name] {
+				chains = append(chains, item)
+				delete(selected, item.name)
+			}
+		}
+		for i := range p.menu.Options {
+			if selected[p.menu.Options[i].Value] {
+				chains = append(chains, &chainGroupItem{name: p.menu.Options[i].Value})
+			}
+		}
+		p.chains = chains
+		p.updateChainSelector()
+	}
+	p.menu.OnAdd = func() {
+		p.service.router.Goto(page.Route{
+			Path: page.PageChain,
+			Perm: page.PermReadWrite,
+		})
+		p.service.router.HideModal(gtx)
+	}
+	p.menu.Multiple = true
+
+	p.service.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+func (p *chainGroup) showSelectorStrategyMenu(gtx page.C) {
+	for i := range page.SelectorStrategyOptions {
+		page.SelectorStrategyOptions[i].Selected = p.selectorStrategy.AnyValue(page.SelectorStrategyOptions[i].Value)
+	}
+
+	p.menu.Title = i18n.SelectorStrategy
+	p.menu.Options = page.SelectorStrategyOptions
+	p.menu.OnClick = func(ok bool) {
+		p.service.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		p.selectorStrategy.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				p.selectorStrategy.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Key: p.menu.Options[i].Key, Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = false
+
+	p.service.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
@@ -21,8 +21,9 @@ type handler struct {
 	service *servicePage
 	menu    ui_widget.Menu
 
-	typ   ui_widget.Selector
-	chain ui_widget.Selector
+	typ        ui_widget.Selector
+	chain      ui_widget.Selector
+	chainGroup *chainGroup
 
 	authType widget.Enum
 	username component.TextField
@@ -32,6 +33,8 @@ type handler struct {
 	limiter  ui_widget.Selector
 	observer ui_widget.Selector
 
+	tls *page.TLSEditor
+
 	metadata          []metadata
 	mdSelector        ui_widget.Selector
 	mdFolded          bool
@@ -45,9 +48,11 @@ func newHandler(service *servicePage) *handler {
 		service:    service,
 		typ:        ui_widget.Selector{Title: i18n.Type},
 		chain:      ui_widget.Selector{Title: i18n.Chain},
+		chainGroup: newChainGroup(service),
 		auther:     ui_widget.Selector{Title: i18n.Auther},
 		limiter:    ui_widget.Selector{Title: i18n.Limiter},
 		observer:   ui_widget.Selector{Title: i18n.Observer},
+		tls:        page.NewTLSEditor(service.router),
 		mdSelector: ui_widget.Selector{Title: i18n.Metadata},
 		mdDialog: ui_widget.MetadataDialog{
 			K: component.TextField{
@@ -82,6 +87,7 @@ func (h *handler) init(cfg *api.HandlerConfig) {
 
 	h.chain.Clear()
 	h.chain.Select(ui_widget.SelectorItem{Value: cfg.Chain})
+	h.chainGroup.init(cfg.ChainGroup)
 
 	{
 		h.username.Clear()
@@ -109,6 +115,8 @@ func (h *handler) init(cfg *api.HandlerConfig) {
 		}
 	}
 
+	h.tls.Init(cfg.TLS)
+
 	h.metadata = nil
 	md := api.NewMetadata(cfg.Metadata)
 	for k := range md {
@@ -144,12 +152,19 @@ func (h *handler) Layout(gtx page.C, th *page.T) page.D {
 		}),
 
 		layout.Rigid(func(gtx page.C) page.D {
+			if h.chainGroup.Enabled() {
+				return page.D{}
+			}
+
 			if h.chain.Clicked(gtx) {
 				h.showChainMenu(gtx)
 			}
 
 			return h.chain.Layout(gtx, th)
 		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			return h.chainGroup.Layout(gtx, th)
+		}),
 
 		// auth for handler
 		layout.Rigid(func(gtx page.C) page.D {
@@ -248,6 +263,15 @@ func (h *handler) Layout(gtx page.C, th *page.T) page.D {
 					}
 					return h.observer.Layout(gtx, th)
 				}),
+
+				layout.Rigid(func(gtx page.C) page.D {
+					return layout.Inset{
+						Top:    4,
+						Bottom: 4,
+					}.Layout(gtx, func(gtx page.C) page.D {
+						return h.tls.Layout(gtx, th)
+					})
+				}),
 			)
 		}),
 
@@ -385,6 +409,7 @@ func (h *handler) layoutMetadata(gtx page.C, th *page.T) page.D {
 func (h *handler) showMetadataDialog(gtx page.C, i int) {
 	h.mdDialog.K.Clear()
 	h.mdDialog.V.Clear()
+	h.mdDialog.Fields = page.MetadataFields(api.HandlerMetadata, h.typ.Value())
 
 	if i >= 0 && i < len(h.metadata) {
 		h.mdDialog.K.SetText(h.metadata[i].k)
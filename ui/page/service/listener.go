@@ -21,18 +21,16 @@ type listener struct {
 	service *servicePage
 	menu    ui_widget.Menu
 
-	typ   ui_widget.Selector
-	chain ui_widget.Selector
+	typ        ui_widget.Selector
+	chain      ui_widget.Selector
+	chainGroup *chainGroup
 
 	authType widget.Enum
 	username component.TextField
 	password component.TextField
 	auther   ui_widget.Selector
 
-	enableTLS   ui_widget.Switcher
-	tlsCertFile component.TextField
-	tlsKeyFile  component.TextField
-	tlsCAFile   component.TextField
+	tls *page.TLSEditor
 
 	metadata          []metadata
 	mdSelector        ui_widget.Selector
@@ -44,29 +42,12 @@ type listener struct {
 
 func newListener(service *servicePage) *listener {
 	return &listener{
-		service:   service,
-		typ:       ui_widget.Selector{Title: i18n.Type},
-		chain:     ui_widget.Selector{Title: i18n.Chain},
-		auther:    ui_widget.Selector{Title: i18n.Auther},
-		enableTLS: ui_widget.Switcher{Title: i18n.TLS},
-		tlsCertFile: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		tlsKeyFile: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		tlsCAFile: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
+		service:    service,
+		typ:        ui_widget.Selector{Title: i18n.Type},
+		chain:      ui_widget.Selector{Title: i18n.Chain},
+		chainGroup: newChainGroup(service),
+		auther:     ui_widget.Selector{Title: i18n.Auther},
+		tls:        page.NewTLSEditor(service.router),
 		mdSelector: ui_widget.Selector{Title: i18n.Metadata},
 		mdDialog: ui_widget.MetadataDialog{
 			K: component.TextField{
@@ -101,6 +82,7 @@ func (l *listener) init(cfg *api.ListenerConfig) {
 
 	l.chain.Clear()
 	l.chain.Select(ui_widget.SelectorItem{Value: cfg.Chain})
+	l.chainGroup.init(cfg.ChainGroup)
 
 	{
 		l.username.Clear()
@@ -127,19 +109,7 @@ func (l *listener) init(cfg *api.ListenerConfig) {
 		}
 	}
 
-	{
-		l.enableTLS.SetValue(false)
-		l.tlsCertFile.Clear()
-		l.tlsKeyFile.Clear()
-		l.tlsCAFile.Clear()
-
-		if tls := cfg.TLS; tls != nil {
-			l.enableTLS.SetValue(true)
-			l.tlsCertFile.SetText(tls.CertFile)
-			l.tlsKeyFile.SetText(tls.KeyFile)
-			l.tlsCAFile.SetText(tls.CAFile)
-		}
-	}
+	l.tls.Init(cfg.TLS)
 
 	l.metadata = nil
 	md := api.NewMetadata(cfg.Metadata)
@@ -179,10 +149,23 @@ func (l *listener) Layout(gtx page.C, th *page.T) page.D {
 				return page.D{}
 			}
 
-			if l.chain.Clicked(gtx) {
-				l.showChainMenu(gtx)
-			}
-			return l.chain.Layout(gtx, th)
+			return layout.Flex{
+				Axis: layout.Vertical,
+			}.Layout(gtx,
+				layout.Rigid(func(gtx page.C) page.D {
+					if l.chainGroup.Enabled() {
+						return page.D{}
+					}
+
+					if l.chain.Clicked(gtx) {
+						l.showChainMenu(gtx)
+					}
+					return l.chain.Layout(gtx, th)
+				}),
+				layout.Rigid(func(gtx page.C) page.D {
+					return l.chainGroup.Layout(gtx, th)
+				}),
+			)
 		}),
 
 		// Auth Config
@@ -270,47 +253,7 @@ func (l *listener) Layout(gtx page.C, th *page.T) page.D {
 				Top:    4,
 				Bottom: 4,
 			}.Layout(gtx, func(gtx page.C) page.D {
-				return layout.Flex{
-					Axis: layout.Vertical,
-				}.Layout(gtx,
-					layout.Rigid(func(gtx page.C) page.D {
-						return l.enableTLS.Layout(gtx, th)
-					}),
-
-					layout.Rigid(func(gtx page.C) page.D {
-						if !l.enableTLS.Value() {
-							return page.D{}
-						}
-						return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
-							return layout.Flex{
-								Axis: layout.Vertical,
-							}.Layout(gtx,
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.CertFile.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return l.tlsCertFile.Layout(gtx, th, "")
-								}),
-								layout.Rigid(layout.Spacer{Height: 8}.Layout),
-
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.KeyFile.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return l.tlsKeyFile.Layout(gtx, th, "")
-								}),
-								layout.Rigid(layout.Spacer{Height: 8}.Layout),
-
-								layout.Rigid(func(gtx page.C) page.D {
-									return material.Body1(th, i18n.CAFile.Value()).Layout(gtx)
-								}),
-								layout.Rigid(func(gtx page.C) page.D {
-									return l.tlsCAFile.Layout(gtx, th, "")
-								}),
-							)
-						})
-					}),
-				)
+				return l.tls.Layout(gtx, th)
 			})
 		}),
 
@@ -448,6 +391,7 @@ func (l *listener) layoutMetadata(gtx page.C, th *page.T) page.D {
 func (l *listener) showMetadataDialog(gtx page.C, i int) {
 	l.mdDialog.K.Clear()
 	l.mdDialog.V.Clear()
+	l.mdDialog.Fields = page.MetadataFields(api.ListenerMetadata, l.typ.Value())
 
 	if i >= 0 && i < len(l.metadata) {
 		l.mdDialog.K.SetText(l.metadata[i].k)
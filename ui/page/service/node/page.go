@@ -38,10 +38,7 @@ type nodePage struct {
 	hostFilter     component.TextField
 	pathFilter     component.TextField
 
-	enableHTTP   ui_widget.Switcher
-	httpHost     component.TextField
-	httpUsername component.TextField
-	httpPassword component.TextField
+	http *page.HTTPNodeEditor
 
 	enableTLS     ui_widget.Switcher
 	tlsSecure     ui_widget.Switcher
@@ -93,25 +90,7 @@ func NewPage(r *page.Router) page.Page {
 				MaxLen:     255,
 			},
 		},
-		enableHTTP: ui_widget.Switcher{Title: i18n.HTTP},
-		httpHost: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		httpUsername: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
-		httpPassword: component.TextField{
-			Editor: widget.Editor{
-				SingleLine: true,
-				MaxLen:     255,
-			},
-		},
+		http: page.NewHTTPNodeEditor(r),
 
 		enableTLS: ui_widget.Switcher{Title: i18n.TLS},
 		tlsSecure: ui_widget.Switcher{Title: i18n.VerifyServerCert},
@@ -196,15 +175,20 @@ func (p *nodePage) Init(opts ...page.PageOption) {
 		p.pathFilter.SetText(filter.Path)
 	}
 
-	p.enableHTTP.SetValue(false)
-	if node.HTTP != nil {
-		p.enableHTTP.SetValue(true)
-		p.httpHost.SetText(node.HTTP.Host)
-		if node.HTTP.Auth != nil {
-			p.httpUsername.SetText(node.HTTP.Auth.Username)
-			p.httpPassword.SetText(node.HTTP.Auth.Password)
+	httpCfg := node.HTTP
+	// the deprecated node auth is migrated into the HTTP settings.
+	if node.Auth != nil {
+		if httpCfg == nil {
+			httpCfg = &api.HTTPNodeConfig{}
+		} else {
+			c := *httpCfg
+			httpCfg = &c
+		}
+		if httpCfg.Auth == nil {
+			httpCfg.Auth = node.Auth
 		}
 	}
+	p.http.Init(httpCfg)
 
 	p.enableTLS.SetValue(false)
 	if node.TLS != nil {
@@ -414,30 +398,7 @@ func (p *nodePage) layout(gtx page.C, th *page.T) page.D {
 						}),
 
 						layout.Rigid(func(gtx page.C) page.D {
-							return p.enableHTTP.Layout(gtx, th)
-						}),
-
-						layout.Rigid(func(gtx page.C) page.D {
-							if !p.enableHTTP.Value() {
-								return page.D{}
-							}
-
-							return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
-								return layout.Flex{
-									Axis: layout.Vertical,
-								}.Layout(gtx,
-									layout.Rigid(func(gtx page.C) page.D {
-										return p.httpHost.Layout(gtx, th, i18n.RewriteHostHeader.Value())
-									}),
-									layout.Rigid(func(gtx page.C) page.D {
-										return p.httpUsername.Layout(gtx, th, i18n.Username.Value())
-									}),
-									layout.Rigid(func(gtx page.C) page.D {
-										return p.httpPassword.Layout(gtx, th, i18n.Password.Value())
-									}),
-									layout.Rigid(layout.Spacer{Height: 4}.Layout),
-								)
-							})
+							return p.http.Layout(gtx, th)
 						}),
 
 						layout.Rigid(func(gtx page.C) page.D {
@@ -563,19 +524,7 @@ func (p *nodePage) generateConfig() *api.ForwardNodeConfig {
 			Path:     strings.TrimSpace(p.pathFilter.Text()),
 		}
 	}
-	if p.enableHTTP.Value() {
-		node.HTTP = &api.HTTPNodeConfig{
-			Host: strings.TrimSpace(p.httpHost.Text()),
-		}
-		username := strings.TrimSpace(p.httpUsername.Text())
-		password := strings.TrimSpace(p.httpPassword.Text())
-		if username != "" {
-			node.HTTP.Auth = &api.AuthConfig{
-				Username: username,
-				Password: password,
-			}
-		}
-	}
+	node.HTTP = p.http.Config()
 	if p.enableTLS.Value() {
 		node.TLS = &api.TLSNodeConfig{
 			ServerName: strings.TrimSpace(p.tlsServerName.Text()),
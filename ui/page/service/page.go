@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
+	"image/color"
 	"strconv"
 	"strings"
 	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
+	"gioui.org/op"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 	"gioui.org/x/component"
@@ -22,6 +25,7 @@ import (
 	"github.com/go-gost/gostctl/ui/theme"
 	ui_widget "github.com/go-gost/gostctl/ui/widget"
 	"github.com/google/uuid"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
 )
 
 type record struct {
@@ -81,6 +85,19 @@ type servicePage struct {
 	edit   bool
 	create bool
 
+	// sub receives the result of the pending save.
+	sub     *runner.Subscription
+	saving  bool
+	saveErr error
+
+	// base is the service as loaded and regenerated by the form, version is its version on the server,
+	// they are used to detect and merge the changes made by someone else meanwhile.
+	base           *api.ServiceConfig
+	version        string
+	pending        *api.ServiceConfig
+	conflictDialog ui_widget.ChoiceDialog
+	deletedDialog  ui_widget.ChoiceDialog
+
 	metadata   []metadata
 	mdSelector ui_widget.Selector
 	mdFolded   bool
@@ -116,7 +133,17 @@ func NewPage(r *page.Router) page.Page {
 				MaxLen:     255,
 			},
 		},
-		delDialog:         ui_widget.Dialog{Title: i18n.DeleteService},
+		delDialog: ui_widget.Dialog{Title: i18n.DeleteService},
+		conflictDialog: ui_widget.ChoiceDialog{
+			Title:   i18n.ServiceConflict,
+			Body:    i18n.ServiceConflictHint,
+			Choices: []i18n.Key{i18n.Merge, i18n.Overwrite},
+		},
+		deletedDialog: ui_widget.ChoiceDialog{
+			Title:   i18n.ServiceDeleted,
+			Body:    i18n.ServiceDeletedHint,
+			Choices: []i18n.Key{i18n.Recreate},
+		},
 		delMetadataDialog: ui_widget.Dialog{Title: i18n.DeleteMetadata},
 		delRecordDialog:   ui_widget.Dialog{Title: i18n.DeleteMetadata},
 
@@ -168,8 +195,11 @@ func NewPage(r *page.Router) page.Page {
 }
 
 func (p *servicePage) Init(opts ...page.PageOption) {
+	p.Exit()
+	p.saveErr = nil
+
 	if server := config.CurrentServer(); server != nil {
-		p.readonly = server.Readonly
+		p.readonly = server.IsReadonly()
 	}
 
 	var options page.PageOptions
@@ -198,12 +228,32 @@ func (p *servicePage) Init(opts ...page.PageOption) {
 			break
 		}
 	}
+	p.base = nil
+	p.version = ""
+
+	p.mode.Value = string(page.BasicMode)
+
 	if service == nil {
-		service = &api.ServiceConfig{}
+		p.load(&api.ServiceConfig{})
+		return
 	}
 
-	p.mode.Value = string(page.BasicMode)
+	p.version = task.Version("service", service.Name)
+	p.base = p.formConfig(service)
+}
+
+// formConfig fills the form with the service and returns the service generated by the form,
+// the services compared by the merge are generated the same way as the service saved.
+func (p *servicePage) formConfig(service *api.ServiceConfig) *api.ServiceConfig {
+	p.load(service)
 
+	cfg := p.generateConfig(service)
+	cfg.Status = nil
+	return cfg
+}
+
+// load fills the form with the service.
+func (p *servicePage) load(service *api.ServiceConfig) {
 	p.name.Clear()
 	p.name.SetText(service.Name)
 
@@ -307,11 +357,10 @@ func (p *servicePage) Layout(gtx page.C) page.D {
 	if p.btnEdit.Clicked(gtx) {
 		p.edit = true
 	}
-	if p.btnSave.Clicked(gtx) {
-		if p.save() {
-			p.router.Back()
-		}
+	if p.btnSave.Clicked(gtx) && !p.saving {
+		p.save()
 	}
+	p.handleSaveEvent(gtx)
 
 	if p.btnDelete.Clicked(gtx) {
 		p.delDialog.OnClick = func(ok bool) {
@@ -383,6 +432,17 @@ func (p *servicePage) Layout(gtx page.C) page.D {
 				)
 			})
 		}),
+		layout.Rigid(func(gtx page.C) page.D {
+			if p.saving {
+				return layout.Inset{Left: 16, Right: 16}.Layout(gtx, material.Body2(th, i18n.Saving.Value()).Layout)
+			}
+			if p.saveErr != nil {
+				label := material.Body2(th, p.saveErr.Error())
+				label.Color = color.NRGBA(colornames.Red500)
+				return layout.Inset{Left: 16, Right: 16}.Layout(gtx, label.Layout)
+			}
+			return page.D{}
+		}),
 		layout.Flexed(1, func(gtx page.C) page.D {
 			return p.list.Layout(gtx, 1, func(gtx page.C, index int) page.D {
 				return layout.UniformInset(8).Layout(gtx, func(gtx page.C) page.D {
@@ -393,11 +453,53 @@ func (p *servicePage) Layout(gtx page.C) page.D {
 	)
 }
 
+// Exit cancels the subscription of the pending save, its result is no longer shown.
+func (p *servicePage) Exit() {
+	p.sub.Unsubscribe()
+	p.sub = nil
+	p.saving = false
+}
+
+func (p *servicePage) handleSaveEvent(gtx page.C) {
+	if p.sub == nil {
+		return
+	}
+
+	select {
+	case e, ok := <-p.sub.Event():
+		if !ok {
+			return
+		}
+		p.Exit()
+		util.RestartGetConfigTask()
+
+		var conflict *task.ConflictError
+		if errors.As(e.Err, &conflict) {
+			p.showConflictDialog(gtx, conflict)
+			return
+		}
+
+		// the save is canceled by another save of the service, or on exit.
+		if errors.Is(e.Err, context.Canceled) {
+			p.saveErr = errors.New(i18n.ErrSaveCanceled.Value())
+			return
+		}
+
+		p.saveErr = e.Err
+		if e.Err == nil {
+			p.router.Back()
+		}
+	default:
+		// poll until the save task is done.
+		gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(100 * time.Millisecond)})
+	}
+}
+
 func (p *servicePage) layout(gtx page.C, th *page.T) page.D {
 	if p.btnConfig.Clicked(gtx) {
 		p.router.Goto(page.Route{
 			Path:  page.PageConfig,
-			Value: p.generateConfig(),
+			Value: p.generateConfig(p.base),
 		})
 	}
 	if p.btnEvent.Clicked(gtx) {
@@ -1230,41 +1332,86 @@ func (p *servicePage) recordCallback(action page.Action, id string, value any) {
 	p.recorderSelector.Select(ui_widget.SelectorItem{Value: strconv.Itoa(len(p.records))})
 }
 
-func (p *servicePage) save() bool {
-	cfg := p.generateConfig()
-
-	var err error
-	if p.id == "" {
-		err = runner.Exec(context.Background(),
-			task.CreateService(cfg),
-			runner.WithCancel(true),
-		)
-	} else {
-		err = runner.Exec(context.Background(),
-			task.UpdateService(cfg),
-			runner.WithCancel(true),
-		)
+func (p *servicePage) showConflictDialog(gtx page.C, conflict *task.ConflictError) {
+	remote, _ := conflict.Remote.(*api.ServiceConfig)
+	if remote == nil {
+		p.showDeletedDialog(gtx)
+		return
 	}
-	util.RestartGetConfigTask()
 
-	return err == nil
+	p.conflictDialog.OnClick = func(choice int) {
+		p.router.HideModal(gtx)
+
+		switch choice {
+		case 0: // merge
+			remote := p.formConfig(remote)
+			merged := &api.ServiceConfig{}
+			if err := api.Merge(p.base, p.pending, remote, merged); err != nil {
+				p.load(p.pending)
+				p.saveErr = err
+				return
+			}
+			p.base = remote
+			p.version = conflict.Version
+			// let the user review the merged service before saving it again.
+			p.load(merged)
+		case 1: // overwrite
+			p.saveService(p.pending, false)
+		}
+	}
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.conflictDialog.Layout(gtx, th)
+	})
 }
 
-func (p *servicePage) generateConfig() *api.ServiceConfig {
-	var svcCfg *api.ServiceConfig
+// showDeletedDialog offers to recreate the service deleted on the server, the edits are kept if it is canceled.
+func (p *servicePage) showDeletedDialog(gtx page.C) {
+	p.deletedDialog.OnClick = func(choice int) {
+		p.router.HideModal(gtx)
 
-	if p.id != "" {
-		for _, svc := range api.GetConfig().Services {
-			if svc == nil {
-				continue
-			}
-			if svc.Name == p.id {
-				svcCfg = svc.Copy()
-				break
-			}
+		if choice == 0 { // recreate
+			p.id = ""
+			p.create = true
+			p.base = nil
+			p.version = ""
+			p.saveService(p.pending, false)
 		}
 	}
+	p.router.ShowModal(gtx, func(gtx page.C, th *page.T) page.D {
+		return p.deletedDialog.Layout(gtx, th)
+	})
+}
+
+func (p *servicePage) save() {
+	p.saveService(p.generateConfig(p.base), true)
+}
+
+// saveService saves the service, the update is checked against the loaded version if check is true.
+func (p *servicePage) saveService(cfg *api.ServiceConfig, check bool) {
+	p.pending = cfg
+
+	t := task.UpdateService(cfg)
+	if p.id == "" {
+		t = task.CreateService(cfg)
+	} else if check {
+		t = task.CheckVersion("service", p.id, p.version, t)
+	}
 
+	p.sub.Unsubscribe()
+	p.sub = runner.Subscribe(runner.FilterResource("service", cfg.Name))
+	p.saving = true
+	p.saveErr = nil
+
+	runner.Exec(context.Background(), t,
+		runner.WithAync(true),
+		runner.WithCancel(true),
+		runner.WithInline(true),
+	)
+}
+
+// generateConfig returns the service edited by the form, the fields not in the form are kept from service.
+func (p *servicePage) generateConfig(service *api.ServiceConfig) *api.ServiceConfig {
+	svcCfg := service.Copy()
 	if svcCfg == nil {
 		svcCfg = &api.ServiceConfig{}
 	}
@@ -1327,6 +1474,10 @@ func (p *servicePage) generateConfig() *api.ServiceConfig {
 
 	svcCfg.Handler.Type = p.handler.typ.Value()
 	svcCfg.Handler.Chain = p.handler.chain.Value()
+	svcCfg.Handler.ChainGroup = p.handler.chainGroup.generateConfig()
+	if svcCfg.Handler.ChainGroup != nil {
+		svcCfg.Handler.Chain = ""
+	}
 
 	svcCfg.Handler.Auther = ""
 	svcCfg.Handler.Authers = nil
@@ -1353,10 +1504,12 @@ func (p *servicePage) generateConfig() *api.ServiceConfig {
 
 	svcCfg.Handler.Limiter = p.handler.limiter.Value()
 	svcCfg.Handler.Observer = p.handler.observer.Value()
+	svcCfg.Handler.TLS = p.handler.tls.Config()
 
 	svcCfg.Handler.Metadata = make(map[string]any)
 	for i := range p.handler.metadata {
-		svcCfg.Handler.Metadata[p.handler.metadata[i].k] = p.handler.metadata[i].v
+		md := &p.handler.metadata[i]
+		svcCfg.Handler.Metadata[md.k] = api.MetadataValue(api.HandlerMetadata, svcCfg.Handler.Type, md.k, md.v)
 	}
 
 	if svcCfg.Listener == nil {
@@ -1365,6 +1518,10 @@ func (p *servicePage) generateConfig() *api.ServiceConfig {
 
 	svcCfg.Listener.Type = p.listener.typ.Value()
 	svcCfg.Listener.Chain = p.listener.chain.Value()
+	svcCfg.Listener.ChainGroup = p.listener.chainGroup.generateConfig()
+	if svcCfg.Listener.ChainGroup != nil {
+		svcCfg.Listener.Chain = ""
+	}
 
 	svcCfg.Listener.Auther = ""
 	svcCfg.Listener.Authers = nil
@@ -1389,18 +1546,12 @@ func (p *servicePage) generateConfig() *api.ServiceConfig {
 		}
 	}
 
-	svcCfg.Listener.TLS = nil
-	if p.listener.enableTLS.Value() {
-		svcCfg.Listener.TLS = &api.TLSConfig{
-			CertFile: strings.TrimSpace(p.listener.tlsCertFile.Text()),
-			KeyFile:  strings.TrimSpace(p.listener.tlsKeyFile.Text()),
-			CAFile:   strings.TrimSpace(p.listener.tlsCAFile.Text()),
-		}
-	}
+	svcCfg.Listener.TLS = p.listener.tls.Config()
 
 	svcCfg.Listener.Metadata = make(map[string]any)
 	for i := range p.listener.metadata {
-		svcCfg.Listener.Metadata[p.listener.metadata[i].k] = p.listener.metadata[i].v
+		md := &p.listener.metadata[i]
+		svcCfg.Listener.Metadata[md.k] = api.MetadataValue(api.ListenerMetadata, svcCfg.Listener.Type, md.k, md.v)
 	}
 
 	svcCfg.Forwarder = nil
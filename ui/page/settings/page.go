@@ -1,6 +1,8 @@
 package settings
 
 import (
+	"time"
+
 	"gioui.org/font"
 	"gioui.org/layout"
 	"gioui.org/widget"
@@ -27,6 +29,13 @@ type settingsPage struct {
 
 	lang  ui_widget.Selector
 	theme ui_widget.Selector
+
+	passphrase       ui_widget.Selector
+	idleLock         ui_widget.Selector
+	passphraseDialog ui_widget.InputDialog
+
+	servers ui_widget.Selector
+	logs    ui_widget.Selector
 }
 
 func NewPage(r *page.Router) page.Page {
@@ -37,11 +46,34 @@ func NewPage(r *page.Router) page.Page {
 				Axis: layout.Vertical,
 			},
 		},
-		lang:  ui_widget.Selector{Title: i18n.Language},
-		theme: ui_widget.Selector{Title: i18n.Theme},
+		lang:       ui_widget.Selector{Title: i18n.Language},
+		theme:      ui_widget.Selector{Title: i18n.Theme},
+		passphrase: ui_widget.Selector{Title: i18n.MasterPassphrase},
+		idleLock:   ui_widget.Selector{Title: i18n.IdleLock},
+		servers:    ui_widget.Selector{Title: i18n.ServerTransfer},
+		logs:       ui_widget.Selector{Title: i18n.Logs},
+		passphraseDialog: ui_widget.InputDialog{
+			Title: i18n.MasterPassphrase,
+			Body:  i18n.MasterPassphraseHint,
+			Input: component.TextField{
+				Editor: widget.Editor{
+					SingleLine: true,
+					MaxLen:     128,
+					Mask:       '*',
+				},
+			},
+		},
 	}
 }
 
+var idleLockOptions = []ui_widget.MenuOption{
+	{Key: i18n.Never, Value: "0s"},
+	{Name: "5m", Value: "5m0s"},
+	{Name: "15m", Value: "15m0s"},
+	{Name: "1h", Value: "1h0m0s"},
+	{Name: "4h", Value: "4h0m0s"},
+}
+
 func (p *settingsPage) Init(opts ...page.PageOption) {
 	settings := config.Get().Settings
 
@@ -56,6 +88,8 @@ func (p *settingsPage) Init(opts ...page.PageOption) {
 		}
 	}
 
+	p.initSecret()
+
 	p.theme.Clear()
 	switch settings.Theme {
 	case theme.Light:
@@ -71,6 +105,29 @@ func (p *settingsPage) Init(opts ...page.PageOption) {
 	}
 }
 
+func (p *settingsPage) initSecret() {
+	secret := config.Get().Secret
+
+	p.passphrase.Clear()
+	if secret != nil && secret.Salt != "" {
+		p.passphrase.Select(ui_widget.SelectorItem{Key: i18n.Passphrase, Value: "passphrase"})
+	} else {
+		p.passphrase.Select(ui_widget.SelectorItem{Key: i18n.SecretKeyFile, Value: "file"})
+	}
+
+	var idleTimeout time.Duration
+	if secret != nil {
+		idleTimeout = secret.IdleTimeout
+	}
+	p.idleLock.Clear()
+	for _, opt := range idleLockOptions {
+		if opt.Value == idleTimeout.String() {
+			p.idleLock.Select(ui_widget.SelectorItem{Key: opt.Key, Name: opt.Name, Value: opt.Value})
+			break
+		}
+	}
+}
+
 func (p *settingsPage) Layout(gtx layout.Context) layout.Dimensions {
 	if p.btnBack.Clicked(gtx) {
 		p.router.Back()
@@ -176,6 +233,34 @@ func (p *settingsPage) layout(gtx layout.Context, th *material.Theme) layout.Dim
 							}
 							return p.theme.Layout(gtx, th)
 						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if p.passphrase.Clicked(gtx) {
+								p.showPassphraseDialog(gtx)
+							}
+							return p.passphrase.Layout(gtx, th)
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if p.idleLock.Clicked(gtx) {
+								p.showIdleLockMenu(gtx)
+							}
+							return p.idleLock.Layout(gtx, th)
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if p.servers.Clicked(gtx) {
+								p.router.Goto(page.Route{
+									Path: page.PageServerTransfer,
+								})
+							}
+							return p.servers.Layout(gtx, th)
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if p.logs.Clicked(gtx) {
+								p.router.Goto(page.Route{
+									Path: page.PageLog,
+								})
+							}
+							return p.logs.Layout(gtx, th)
+						}),
 					)
 				})
 			})
@@ -302,3 +387,59 @@ func (p *settingsPage) showThemeMenu(gtx layout.Context) {
 		return p.menu.Layout(gtx, th)
 	})
 }
+
+func (p *settingsPage) showPassphraseDialog(gtx layout.Context) {
+	p.passphraseDialog.Input.Clear()
+	p.passphraseDialog.OnClick = func(ok bool) {
+		if !ok {
+			p.router.HideModal(gtx)
+			return
+		}
+
+		var idleTimeout time.Duration
+		if secret := config.Get().Secret; secret != nil {
+			idleTimeout = secret.IdleTimeout
+		}
+		if err := config.SetPassphrase(p.passphraseDialog.Input.Text(), idleTimeout); err != nil {
+			p.passphraseDialog.Input.SetError(err.Error())
+			return
+		}
+		p.passphraseDialog.Input.Clear()
+		p.router.HideModal(gtx)
+		p.initSecret()
+	}
+
+	p.router.ShowModal(gtx, p.passphraseDialog.Layout)
+}
+
+func (p *settingsPage) showIdleLockMenu(gtx layout.Context) {
+	options := idleLockOptions
+	for i := range options {
+		options[i].Selected = p.idleLock.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = i18n.IdleLock
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		for i := range p.menu.Options {
+			if !p.menu.Options[i].Selected {
+				continue
+			}
+			idleTimeout, _ := time.ParseDuration(p.menu.Options[i].Value)
+			config.SetIdleTimeout(idleTimeout)
+			break
+		}
+		p.initSecret()
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = false
+
+	p.router.ShowModal(gtx, func(gtx page.C, th *material.Theme) page.D {
+		return p.menu.Layout(gtx, th)
+	})
+}
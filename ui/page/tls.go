@@ -0,0 +1,388 @@
+package page
+
+import (
+	"crypto/tls"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+	"github.com/go-gost/gostctl/api"
+	"github.com/go-gost/gostctl/ui/i18n"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
+)
+
+var (
+	TLSVersionOptions = []ui_widget.MenuOption{
+		{Name: tls.VersionName(tls.VersionTLS10), Value: "VersionTLS10"},
+		{Name: tls.VersionName(tls.VersionTLS11), Value: "VersionTLS11"},
+		{Name: tls.VersionName(tls.VersionTLS12), Value: "VersionTLS12"},
+		{Name: tls.VersionName(tls.VersionTLS13), Value: "VersionTLS13"},
+	}
+
+	TLSCipherSuiteOptions = tlsCipherSuiteOptions()
+)
+
+func tlsCipherSuiteOptions() (options []ui_widget.MenuOption) {
+	for _, cs := range tls.CipherSuites() {
+		options = append(options, ui_widget.MenuOption{
+			Name:  cs.Name,
+			Value: cs.Name,
+		})
+	}
+	for _, cs := range tls.InsecureCipherSuites() {
+		options = append(options, ui_widget.MenuOption{
+			Name:    cs.Name,
+			Value:   cs.Name,
+			DescKey: i18n.TLSCipherInsecure,
+		})
+	}
+	return
+}
+
+// TLSEditor edits an api.TLSConfig. It is embedded by the pages
+// that have a TLS section, such as listener, handler, dialer, connector and plugin.
+type TLSEditor struct {
+	router *Router
+	menu   ui_widget.Menu
+
+	enable     ui_widget.Switcher
+	secure     ui_widget.Switcher
+	serverName component.TextField
+	certFile   component.TextField
+	keyFile    component.TextField
+	caFile     component.TextField
+
+	minVersion   ui_widget.Selector
+	maxVersion   ui_widget.Selector
+	cipherSuites ui_widget.Selector
+
+	autoCert ui_widget.Switcher
+	validity component.TextField
+	// loadedValidity is the validity of the config loaded, it is kept as is if the days are not edited,
+	// as it may not be a whole number of days.
+	loadedValidity time.Duration
+	commonName     component.TextField
+	organization   component.TextField
+}
+
+func NewTLSEditor(r *Router) *TLSEditor {
+	return &TLSEditor{
+		router: r,
+		enable: ui_widget.Switcher{Title: i18n.TLS},
+		secure: ui_widget.Switcher{Title: i18n.VerifyServerCert},
+		serverName: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		certFile: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		keyFile: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		caFile: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		minVersion:   ui_widget.Selector{Title: i18n.TLSMinVersion},
+		maxVersion:   ui_widget.Selector{Title: i18n.TLSMaxVersion},
+		cipherSuites: ui_widget.Selector{Title: i18n.TLSCipherSuites},
+		autoCert:     ui_widget.Switcher{Title: i18n.TLSAutoCert},
+		validity: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     8,
+				Filter:     "1234567890",
+			},
+		},
+		commonName: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+		organization: component.TextField{
+			Editor: widget.Editor{
+				SingleLine: true,
+				MaxLen:     255,
+			},
+		},
+	}
+}
+
+func (p *TLSEditor) Init(cfg *api.TLSConfig) {
+	p.enable.SetValue(false)
+	p.secure.SetValue(false)
+	p.serverName.Clear()
+	p.certFile.Clear()
+	p.keyFile.Clear()
+	p.caFile.Clear()
+	p.minVersion.Clear()
+	p.maxVersion.Clear()
+	p.cipherSuites.Clear()
+	p.autoCert.SetValue(false)
+	p.validity.Clear()
+	p.loadedValidity = 0
+	p.commonName.Clear()
+	p.organization.Clear()
+
+	if cfg == nil {
+		return
+	}
+
+	p.enable.SetValue(true)
+	p.secure.SetValue(cfg.Secure)
+	p.serverName.SetText(cfg.ServerName)
+	p.certFile.SetText(cfg.CertFile)
+	p.keyFile.SetText(cfg.KeyFile)
+	p.caFile.SetText(cfg.CAFile)
+
+	if opts := cfg.Options; opts != nil {
+		p.minVersion.Select(tlsVersionItem(opts.MinVersion))
+		p.maxVersion.Select(tlsVersionItem(opts.MaxVersion))
+		for _, cs := range opts.CipherSuites {
+			p.cipherSuites.Select(ui_widget.SelectorItem{Name: cs, Value: cs})
+		}
+	}
+
+	if cfg.Validity > 0 || cfg.CommonName != "" || cfg.Organization != "" {
+		p.autoCert.SetValue(true)
+		if cfg.Validity > 0 {
+			p.loadedValidity = cfg.Validity
+			p.validity.SetText(validityDays(cfg.Validity))
+		}
+		p.commonName.SetText(cfg.CommonName)
+		p.organization.SetText(cfg.Organization)
+	}
+}
+
+func tlsVersionItem(version string) ui_widget.SelectorItem {
+	for i := range TLSVersionOptions {
+		if TLSVersionOptions[i].Value == version {
+			return ui_widget.SelectorItem{Name: TLSVersionOptions[i].Name, Value: TLSVersionOptions[i].Value}
+		}
+	}
+	return ui_widget.SelectorItem{Value: version}
+}
+
+// validityDays returns the validity in days, rounded up.
+func validityDays(validity time.Duration) string {
+	day := 24 * time.Hour
+	return strconv.Itoa(int((validity + day - 1) / day))
+}
+
+// Config returns the edited TLS config, or nil if TLS is disabled.
+func (p *TLSEditor) Config() *api.TLSConfig {
+	if !p.enable.Value() {
+		return nil
+	}
+
+	cfg := &api.TLSConfig{
+		Secure:     p.secure.Value(),
+		ServerName: strings.TrimSpace(p.serverName.Text()),
+		CertFile:   strings.TrimSpace(p.certFile.Text()),
+		KeyFile:    strings.TrimSpace(p.keyFile.Text()),
+		CAFile:     strings.TrimSpace(p.caFile.Text()),
+	}
+
+	opts := &api.TLSOptions{
+		MinVersion:   p.minVersion.Value(),
+		MaxVersion:   p.maxVersion.Value(),
+		CipherSuites: p.cipherSuites.Values(),
+	}
+	if opts.MinVersion != "" || opts.MaxVersion != "" || len(opts.CipherSuites) > 0 {
+		cfg.Options = opts
+	}
+
+	if p.autoCert.Value() {
+		if text := p.validity.Text(); p.loadedValidity > 0 && text == validityDays(p.loadedValidity) {
+			cfg.Validity = p.loadedValidity
+		} else {
+			validity, _ := strconv.Atoi(text)
+			cfg.Validity = time.Duration(validity) * 24 * time.Hour
+		}
+		cfg.CommonName = strings.TrimSpace(p.commonName.Text())
+		cfg.Organization = strings.TrimSpace(p.organization.Text())
+	}
+
+	return cfg
+}
+
+func (p *TLSEditor) Layout(gtx C, th *T) D {
+	if p.minVersion.Clicked(gtx) {
+		p.showVersionMenu(gtx, i18n.TLSMinVersion, &p.minVersion)
+	}
+	if p.maxVersion.Clicked(gtx) {
+		p.showVersionMenu(gtx, i18n.TLSMaxVersion, &p.maxVersion)
+	}
+	if p.cipherSuites.Clicked(gtx) {
+		p.showCipherSuiteMenu(gtx)
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return p.enable.Layout(gtx, th)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !p.enable.Value() {
+				return D{}
+			}
+
+			return layout.UniformInset(8).Layout(gtx, func(gtx C) D {
+				return layout.Flex{
+					Axis: layout.Vertical,
+				}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return p.secure.Layout(gtx, th)
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+					layout.Rigid(material.Body1(th, i18n.ServerName.Value()).Layout),
+					layout.Rigid(func(gtx C) D {
+						return p.serverName.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+					layout.Rigid(material.Body1(th, i18n.CertFile.Value()).Layout),
+					layout.Rigid(func(gtx C) D {
+						return p.certFile.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+					layout.Rigid(material.Body1(th, i18n.KeyFile.Value()).Layout),
+					layout.Rigid(func(gtx C) D {
+						return p.keyFile.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+					layout.Rigid(material.Body1(th, i18n.CAFile.Value()).Layout),
+					layout.Rigid(func(gtx C) D {
+						return p.caFile.Layout(gtx, th, "")
+					}),
+					layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+					layout.Rigid(func(gtx C) D {
+						return p.minVersion.Layout(gtx, th)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return p.maxVersion.Layout(gtx, th)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return p.cipherSuites.Layout(gtx, th)
+					}),
+
+					layout.Rigid(func(gtx C) D {
+						return p.autoCert.Layout(gtx, th)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if !p.autoCert.Value() {
+							return D{}
+						}
+
+						return layout.UniformInset(8).Layout(gtx, func(gtx C) D {
+							return layout.Flex{
+								Axis: layout.Vertical,
+							}.Layout(gtx,
+								layout.Rigid(material.Body2(th, i18n.TLSAutoCertHint.Value()).Layout),
+								layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+								layout.Rigid(material.Body1(th, i18n.TLSValidity.Value()).Layout),
+								layout.Rigid(func(gtx C) D {
+									return p.validity.Layout(gtx, th, "")
+								}),
+								layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+								layout.Rigid(material.Body1(th, i18n.TLSCommonName.Value()).Layout),
+								layout.Rigid(func(gtx C) D {
+									return p.commonName.Layout(gtx, th, "")
+								}),
+								layout.Rigid(layout.Spacer{Height: 8}.Layout),
+
+								layout.Rigid(material.Body1(th, i18n.TLSOrganization.Value()).Layout),
+								layout.Rigid(func(gtx C) D {
+									return p.organization.Layout(gtx, th, "")
+								}),
+							)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
+func (p *TLSEditor) showVersionMenu(gtx C, title i18n.Key, selector *ui_widget.Selector) {
+	options := make([]ui_widget.MenuOption, len(TLSVersionOptions))
+	copy(options, TLSVersionOptions)
+	for i := range options {
+		options[i].Selected = selector.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = title
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		selector.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				selector.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = false
+
+	p.router.ShowModal(gtx, func(gtx C, th *T) D {
+		return p.menu.Layout(gtx, th)
+	})
+}
+
+func (p *TLSEditor) showCipherSuiteMenu(gtx C) {
+	options := make([]ui_widget.MenuOption, len(TLSCipherSuiteOptions))
+	copy(options, TLSCipherSuiteOptions)
+	for i := range options {
+		options[i].Selected = p.cipherSuites.AnyValue(options[i].Value)
+	}
+
+	p.menu.Title = i18n.TLSCipherSuites
+	p.menu.Options = options
+	p.menu.OnClick = func(ok bool) {
+		p.router.HideModal(gtx)
+		if !ok {
+			return
+		}
+
+		p.cipherSuites.Clear()
+		for i := range p.menu.Options {
+			if p.menu.Options[i].Selected {
+				p.cipherSuites.Select(ui_widget.SelectorItem{Name: p.menu.Options[i].Name, Value: p.menu.Options[i].Value})
+			}
+		}
+	}
+	p.menu.OnAdd = nil
+	p.menu.Multiple = true
+
+	p.router.ShowModal(gtx, func(gtx C, th *T) D {
+		return p.menu.Layout(gtx, th)
+	})
+}
@@ -1,16 +1,23 @@
 package ui
 
 import (
+	"fmt"
+	"sync/atomic"
+
 	"gioui.org/app"
 	"gioui.org/layout"
 	"gioui.org/text"
+	"gioui.org/widget"
 	"gioui.org/widget/material"
+	"gioui.org/x/component"
 	"gioui.org/x/pref/locale"
 	gio_theme "gioui.org/x/pref/theme"
+	"github.com/go-gost/gostctl/api/util"
 	"github.com/go-gost/gostctl/config"
 	"github.com/go-gost/gostctl/ui/fonts"
 	"github.com/go-gost/gostctl/ui/i18n"
 	"github.com/go-gost/gostctl/ui/page"
+	"github.com/go-gost/gostctl/ui/page/activity"
 	"github.com/go-gost/gostctl/ui/page/admission"
 	"github.com/go-gost/gostctl/ui/page/auther"
 	"github.com/go-gost/gostctl/ui/page/auther/auth"
@@ -24,18 +31,26 @@ import (
 	"github.com/go-gost/gostctl/ui/page/hosts/mapping"
 	"github.com/go-gost/gostctl/ui/page/limiter"
 	"github.com/go-gost/gostctl/ui/page/limiter/limit"
+	page_log "github.com/go-gost/gostctl/ui/page/log"
 	"github.com/go-gost/gostctl/ui/page/matcher"
+	"github.com/go-gost/gostctl/ui/page/migration"
 	"github.com/go-gost/gostctl/ui/page/node"
 	"github.com/go-gost/gostctl/ui/page/observer"
+	"github.com/go-gost/gostctl/ui/page/pending"
 	"github.com/go-gost/gostctl/ui/page/recorder"
 	"github.com/go-gost/gostctl/ui/page/resolver"
 	"github.com/go-gost/gostctl/ui/page/resolver/nameserver"
 	"github.com/go-gost/gostctl/ui/page/server"
+	"github.com/go-gost/gostctl/ui/page/server/schedule"
+	"github.com/go-gost/gostctl/ui/page/server/schedules"
+	server_settings "github.com/go-gost/gostctl/ui/page/server/settings"
+	"github.com/go-gost/gostctl/ui/page/server/transfer"
 	"github.com/go-gost/gostctl/ui/page/service"
 	forwarder_node "github.com/go-gost/gostctl/ui/page/service/node"
 	"github.com/go-gost/gostctl/ui/page/service/record"
 	"github.com/go-gost/gostctl/ui/page/settings"
 	"github.com/go-gost/gostctl/ui/theme"
+	ui_widget "github.com/go-gost/gostctl/ui/widget"
 	"golang.org/x/text/language"
 )
 
@@ -45,6 +60,10 @@ type D = layout.Dimensions
 type UI struct {
 	w      *app.Window
 	router *page.Router
+
+	unlockDialog ui_widget.InputDialog
+	// unlockPrompted is set once the unlock dialog is shown for the current lock.
+	unlockPrompted atomic.Bool
 }
 
 func NewUI() *UI {
@@ -113,21 +132,112 @@ func NewUI() *UI {
 	router.Register(page.PageEvent, page_event.NewPage(router))
 	router.Register(page.PageConfig, page_config.NewPage(router))
 	router.Register(page.PageSettings, settings.NewPage(router))
+	router.Register(page.PageServerSettings, server_settings.NewPage(router))
+	router.Register(page.PageMigration, migration.NewPage(router))
+	router.Register(page.PageActivity, activity.NewPage(router))
+	router.Register(page.PagePending, pending.NewPage(router))
+	router.Register(page.PageSchedules, schedules.NewPage(router))
+	router.Register(page.PageSchedule, schedule.NewPage(router))
+	router.Register(page.PageServerTransfer, transfer.NewPage(router))
+	router.Register(page.PageLog, page_log.NewPage(router))
 
 	router.Goto(page.Route{
 		Path: page.PageHome,
 	})
 
-	return &UI{
+	ui := &UI{
 		w:      w,
 		router: router,
+		unlockDialog: ui_widget.InputDialog{
+			Title: i18n.Unlock,
+			Body:  i18n.UnlockHint,
+			Input: component.TextField{
+				Editor: widget.Editor{
+					SingleLine: true,
+					MaxLen:     128,
+					Mask:       '*',
+				},
+			},
+		},
 	}
+	// the polling with the forgotten credentials is stopped until the secrets are unlocked again.
+	config.OnLock(func() {
+		util.RestartGetConfigTask()
+		ui.unlockPrompted.Store(false)
+		w.Invalidate()
+	})
+
+	// the config changed by others, e.g. a synced config file, is applied as it is reloaded.
+	config.OnChange(func(e config.ChangeEvent) {
+		if e.ServerChanged {
+			util.RestartGetConfigTask()
+		}
+		if e.Merged {
+			router.Notify(ui_widget.Message{
+				Type:    ui_widget.Warn,
+				Content: fmt.Sprintf("%s %s", i18n.ConfigMerged.Value(), e.Conflict),
+			})
+		} else if e.Conflict != "" {
+			router.Notify(ui_widget.Message{
+				Type:    ui_widget.Warn,
+				Content: fmt.Sprintf("%s %s", i18n.ConfigConflict.Value(), e.Conflict),
+			})
+		} else {
+			router.Notify(ui_widget.Message{
+				Type:    ui_widget.Info,
+				Content: i18n.ConfigReloaded.Value(),
+			})
+		}
+		w.Invalidate()
+	})
+
+	return ui
 }
 
 func (ui *UI) Layout(gtx C) D {
+	if config.Locked() && !ui.unlockPrompted.Swap(true) {
+		ui.showUnlockDialog(gtx)
+	}
 	return ui.router.Layout(gtx)
 }
 
+func (ui *UI) showUnlockDialog(gtx C) {
+	ui.unlockDialog.Input.Clear()
+	ui.unlockDialog.OnClick = func(ok bool) {
+		if !ok {
+			ui.router.HideModal(gtx)
+			return
+		}
+
+		if err := config.Unlock(ui.unlockDialog.Input.Text()); err != nil {
+			ui.unlockDialog.Input.SetError(i18n.ErrBadPassphrase.Value())
+			return
+		}
+		ui.unlockDialog.Input.Clear()
+		ui.router.HideModal(gtx)
+		util.RestartGetConfigTask()
+	}
+
+	ui.router.ShowModal(gtx, ui.unlockDialog.Layout)
+}
+
+// OpenLink handles a link opened by the app, e.g. passed on the command line by the system.
+// A server link opens the server page filled with the server, to be added by the user.
+func (ui *UI) OpenLink(link string) error {
+	server, err := config.ParseServerLink(link)
+	if err != nil {
+		return err
+	}
+
+	ui.router.Goto(page.Route{
+		Path:  page.PageServer,
+		Value: server,
+		Perm:  page.PermReadWrite,
+	})
+	ui.w.Invalidate()
+	return nil
+}
+
 func (ui *UI) Window() *app.Window {
 	return ui.w
 }
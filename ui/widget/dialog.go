@@ -280,3 +280,116 @@ func (p *InputDialog) Layout(gtx layout.Context, th *material.Theme) layout.Dime
 		})
 	})
 }
+
+// ChoiceDialog is a dialog with a button for each of the choices besides the cancel button.
+type ChoiceDialog struct {
+	Title   i18n.Key
+	Body    i18n.Key
+	Choices []i18n.Key
+	// OnClick is called with the index of the clicked choice, or -1 if the dialog is canceled.
+	OnClick    func(choice int)
+	btnCancel  widget.Clickable
+	btnChoices []widget.Clickable
+}
+
+func (p *ChoiceDialog) Layout(gtx layout.Context, th *material.Theme) layout.Dimensions {
+	if len(p.btnChoices) != len(p.Choices) {
+		p.btnChoices = make([]widget.Clickable, len(p.Choices))
+	}
+
+	button := func(btn *widget.Clickable, text i18n.Key) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Left: 8,
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return material.ButtonLayoutStyle{
+					Background:   th.Bg,
+					CornerRadius: 18,
+					Button:       btn,
+				}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{
+						Top:    8,
+						Bottom: 8,
+						Left:   20,
+						Right:  20,
+					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body1(th, text.Value())
+						label.Color = th.Fg
+						return label.Layout(gtx)
+					})
+				})
+			})
+		})
+	}
+
+	if p.btnCancel.Clicked(gtx) && p.OnClick != nil {
+		p.OnClick(-1)
+	}
+	for i := range p.btnChoices {
+		if p.btnChoices[i].Clicked(gtx) && p.OnClick != nil {
+			p.OnClick(i)
+		}
+	}
+
+	buttons := []layout.FlexChild{
+		layout.Flexed(1, layout.Spacer{Width: 8}.Layout),
+		button(&p.btnCancel, i18n.Cancel),
+	}
+	for i := range p.Choices {
+		buttons = append(buttons, button(&p.btnChoices[i], p.Choices[i]))
+	}
+
+	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Inset{
+			Top:    16,
+			Bottom: 16,
+		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return component.SurfaceStyle{
+				Theme: th,
+				ShadowStyle: component.ShadowStyle{
+					CornerRadius: 28,
+				},
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{
+					Top:    16,
+					Bottom: 16,
+					Left:   24,
+					Right:  24,
+				}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{
+						Axis: layout.Vertical,
+					}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if p.Title == "" {
+								return layout.Dimensions{}
+							}
+							return layout.Inset{
+								Top:    8,
+								Bottom: 8,
+							}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								return material.H6(th, p.Title.Value()).Layout(gtx)
+							})
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if p.Body == "" {
+								return layout.Dimensions{}
+							}
+							return layout.Inset{
+								Top:    8,
+								Bottom: 8,
+							}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								return material.Body1(th, p.Body.Value()).Layout(gtx)
+							})
+						}),
+						layout.Rigid(layout.Spacer{Height: 8}.Layout),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return layout.Flex{
+								Alignment: layout.Middle,
+							}.Layout(gtx, buttons...)
+						}),
+					)
+				})
+			})
+		})
+	})
+}
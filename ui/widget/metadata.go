@@ -1,22 +1,69 @@
 package widget
 
 import (
+	"image/color"
+	"slices"
+	"strings"
+
+	"gioui.org/font"
 	"gioui.org/layout"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 	"gioui.org/x/component"
 	"github.com/go-gost/gostctl/ui/i18n"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
 )
 
+// maxKeySuggestions is the maximum number of the known keys suggested for the key being typed.
+const maxKeySuggestions = 5
+
+// MetadataField is a known metadata key, for the completion of the key and the checking of the value.
+type MetadataField struct {
+	Key string
+	// Type is the name of the value type shown along with the key.
+	Type    string
+	Default string
+	Desc    string
+	// Values are the allowed values, any value of Type is allowed if empty.
+	Values []string
+	// Choices are the values offered to choose from, they default to Values.
+	Choices []string
+	// Check reports an error if the value is not of Type, the value is not checked if it is nil.
+	Check func(v string) error
+}
+
 type MetadataDialog struct {
-	K         component.TextField
-	V         component.TextField
+	// Title, KeyTitle and ValueTitle default to the metadata labels if empty.
+	Title      i18n.Key
+	KeyTitle   i18n.Key
+	ValueTitle i18n.Key
+	K          component.TextField
+	V          component.TextField
+	// Fields are the known keys of the component type, for the completion of the key and the checking of the value.
+	Fields    []MetadataField
 	OnClick   func(ok bool)
 	btnCancel widget.Clickable
 	btnOK     widget.Clickable
+	keys      []widget.Clickable
+	values    []widget.Clickable
 }
 
 func (p *MetadataDialog) Layout(gtx layout.Context, th *material.Theme) layout.Dimensions {
+	title, keyTitle, valueTitle := p.Title, p.KeyTitle, p.ValueTitle
+	if title == "" {
+		title = i18n.Metadata
+	}
+	if keyTitle == "" {
+		keyTitle = i18n.MetadataKey
+	}
+	if valueTitle == "" {
+		valueTitle = i18n.MetadataValue
+	}
+
+	field, known := p.field()
+	suggestions := p.suggestions()
+	valid := p.check(field, known)
+
 	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Inset{
 			Top:    16,
@@ -45,7 +92,7 @@ func (p *MetadataDialog) Layout(gtx layout.Context, th *material.Theme) layout.D
 								return layout.Flex{
 									Alignment: layout.Middle,
 								}.Layout(gtx,
-									layout.Flexed(1, material.H6(th, i18n.Metadata.Value()).Layout),
+									layout.Flexed(1, material.H6(th, title.Value()).Layout),
 								)
 							})
 						}),
@@ -60,16 +107,28 @@ func (p *MetadataDialog) Layout(gtx layout.Context, th *material.Theme) layout.D
 								return layout.Flex{
 									Axis: layout.Vertical,
 								}.Layout(gtx,
-									layout.Rigid(material.Body1(th, i18n.MetadataKey.Value()).Layout),
+									layout.Rigid(material.Body1(th, keyTitle.Value()).Layout),
 									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 										return p.K.Layout(gtx, th, "")
 									}),
+									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+										return p.layoutSuggestions(gtx, th, suggestions)
+									}),
+									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+										return p.layoutField(gtx, th, field, known)
+									}),
 									layout.Rigid(layout.Spacer{Height: 8}.Layout),
 
-									layout.Rigid(material.Body1(th, i18n.MetadataValue.Value()).Layout),
+									layout.Rigid(material.Body1(th, valueTitle.Value()).Layout),
 									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 										return p.V.Layout(gtx, th, "")
 									}),
+									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+										if !known {
+											return layout.Dimensions{}
+										}
+										return p.layoutValues(gtx, th, field)
+									}),
 								)
 							})
 						}),
@@ -115,7 +174,7 @@ func (p *MetadataDialog) Layout(gtx layout.Context, th *material.Theme) layout.D
 										return layout.Spacer{Width: 8}.Layout(gtx)
 									}),
 									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-										if p.btnOK.Clicked(gtx) && p.OnClick != nil {
+										if p.btnOK.Clicked(gtx) && p.OnClick != nil && valid {
 											p.OnClick(true)
 										}
 
@@ -146,3 +205,164 @@ func (p *MetadataDialog) Layout(gtx layout.Context, th *material.Theme) layout.D
 		})
 	})
 }
+
+// field returns the known field of the key.
+func (p *MetadataDialog) field() (MetadataField, bool) {
+	key := strings.TrimSpace(p.K.Text())
+	if key == "" {
+		return MetadataField{}, false
+	}
+	for _, field := range p.Fields {
+		if strings.EqualFold(field.Key, key) {
+			return field, true
+		}
+	}
+	return MetadataField{}, false
+}
+
+// suggestions returns the known keys containing the key being typed.
+func (p *MetadataDialog) suggestions() (keys []string) {
+	key := strings.ToLower(strings.TrimSpace(p.K.Text()))
+	if key == "" {
+		return
+	}
+	for _, field := range p.Fields {
+		k := strings.ToLower(field.Key)
+		if k == key {
+			return nil
+		}
+		if strings.Contains(k, key) && len(keys) < maxKeySuggestions {
+			keys = append(keys, field.Key)
+		}
+	}
+	return
+}
+
+// check checks the value by the type of the known field, the error is shown along with the value.
+func (p *MetadataDialog) check(field MetadataField, known bool) bool {
+	v := strings.TrimSpace(p.V.Text())
+	if !known || v == "" {
+		p.V.ClearError()
+		return true
+	}
+
+	switch {
+	case field.Check != nil && field.Check(v) != nil:
+		p.V.SetError(i18n.ErrMetadataType.Value() + " " + field.Type)
+		return false
+	case len(field.Values) > 0 && !slices.Contains(field.Values, v):
+		p.V.SetError(i18n.ErrMetadataValues.Value() + " " + strings.Join(field.Values, ", "))
+		return false
+	}
+	p.V.ClearError()
+	return true
+}
+
+func (p *MetadataDialog) layoutSuggestions(gtx layout.Context, th *material.Theme, keys []string) layout.Dimensions {
+	if len(keys) == 0 {
+		return layout.Dimensions{}
+	}
+
+	if len(p.keys) < len(keys) {
+		p.keys = make([]widget.Clickable, len(keys))
+	}
+	for i := range keys {
+		if p.keys[i].Clicked(gtx) {
+			p.K.SetText(keys[i])
+		}
+	}
+
+	var children []layout.FlexChild
+	for i := range keys {
+		k := keys[i]
+		btn := &p.keys[i]
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return material.Clickable(gtx, btn, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.UniformInset(8).Layout(gtx, material.Body2(th, k).Layout)
+			})
+		}))
+	}
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx, children...)
+}
+
+func (p *MetadataDialog) layoutField(gtx layout.Context, th *material.Theme, field MetadataField, known bool) layout.Dimensions {
+	if strings.TrimSpace(p.K.Text()) == "" || len(p.Fields) == 0 {
+		return layout.Dimensions{}
+	}
+
+	if !known {
+		label := material.Caption(th, i18n.MetadataUnknownKey.Value())
+		label.Color = color.NRGBA(colornames.Orange500)
+		return layout.Inset{Top: 4}.Layout(gtx, label.Layout)
+	}
+
+	info := field.Type
+	if field.Default != "" {
+		info += ", " + i18n.MetadataDefault.Value() + ": " + field.Default
+	}
+	return layout.Inset{Top: 4}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{
+			Axis: layout.Vertical,
+		}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(th, info)
+				label.Font.Weight = font.SemiBold
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(material.Caption(th, field.Desc).Layout),
+		)
+	})
+}
+
+// layoutValues lays out the values of the field to choose from.
+func (p *MetadataDialog) layoutValues(gtx layout.Context, th *material.Theme, field MetadataField) layout.Dimensions {
+	values := field.Choices
+	if len(values) == 0 {
+		values = field.Values
+	}
+	if len(values) == 0 {
+		return layout.Dimensions{}
+	}
+
+	if len(p.values) < len(values) {
+		p.values = make([]widget.Clickable, len(values))
+	}
+	for i := range values {
+		if p.values[i].Clicked(gtx) {
+			p.V.SetText(values[i])
+		}
+	}
+
+	var children []layout.FlexChild
+	for i := range values {
+		v := values[i]
+		btn := &p.values[i]
+		children = append(children,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return material.ButtonLayoutStyle{
+					Background:   th.Bg,
+					CornerRadius: 18,
+					Button:       btn,
+				}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{
+						Top:    4,
+						Bottom: 4,
+						Left:   12,
+						Right:  12,
+					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body2(th, v)
+						label.Color = th.Fg
+						return label.Layout(gtx)
+					})
+				})
+			}),
+			layout.Rigid(layout.Spacer{Width: 8}.Layout),
+		)
+	}
+	return layout.Inset{Top: 8}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{}.Layout(gtx, children...)
+	})
+}